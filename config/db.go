@@ -1,32 +1,32 @@
-package config
-
-import (
-	"context"
-	"log"
-	"os"
-	"time"
-
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
-)
-
-var DB *mongo.Database
-
-func ConnectDB() *mongo.Database {
-	uri := os.Getenv("MONGOSTRING")
-	if uri == "" {
-		log.Fatal("MONGOSTRING not set in environment")
-	}
-	client, err := mongo.NewClient(options.Client().ApplyURI(uri))
-	if err != nil {
-		log.Fatal(err)
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if err := client.Connect(ctx); err != nil {
-		log.Fatal(err)
-	}
-	db := client.Database("ukm_kampus_ulbi")
-	DB = db
-	return db
-}
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var DB *mongo.Database
+
+func ConnectDB() *mongo.Database {
+	uri := os.Getenv("MONGOSTRING")
+	if uri == "" {
+		log.Fatal("MONGOSTRING not set in environment")
+	}
+	client, err := mongo.NewClient(options.Client().ApplyURI(uri))
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		log.Fatal(err)
+	}
+	db := client.Database("ukm_kampus_ulbi")
+	DB = db
+	return db
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes membuat index yang dibutuhkan aplikasi jika belum ada
+func EnsureIndexes(db *mongo.Database) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := map[string][]mongo.IndexModel{
+		"lpj": {
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		"panitia": {
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		"tugas": {
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}}},
+			{Keys: bson.D{{Key: "assignee_id", Value: 1}, {Key: "status", Value: 1}}},
+		},
+		"feedback": {
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		"template_sertifikat": {
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		"sertifikat": {
+			{Keys: bson.D{{Key: "serial", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		"jobs": {
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}}},
+			{Keys: bson.D{{Key: "unique_key", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		},
+		"template_kegiatan": {
+			{Keys: bson.D{{Key: "ukm", Value: 1}, {Key: "nama", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		"tamu": {
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}, {Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "email", Value: 1}}},
+		},
+		"kehadiran": {
+			// deleted_at ikut dalam key agar data di trash tidak bentrok dengan kehadiran aktif, lihat WaktuHapusKehadiran
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "kegiatan_id", Value: 1}, {Key: "deleted_at", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}}},
+			{Keys: bson.D{{Key: "verifikasi", Value: 1}}, Options: options.Index().SetSparse(true)},
+			{Keys: bson.D{{Key: "client_id", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		},
+		"anggaran": {
+			{Keys: bson.D{{Key: "kegiatan_id", Value: 1}, {Key: "jenis", Value: 1}}},
+		},
+	}
+	for collection, models := range indexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			log.Println("Gagal membuat index", collection, ":", err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// toObjectID menerima referensi lama berupa string hex maupun ObjectID
+func toObjectID(value interface{}) (primitive.ObjectID, bool) {
+	switch v := value.(type) {
+	case primitive.ObjectID:
+		return v, true
+	case string:
+		id, err := primitive.ObjectIDFromHex(v)
+		return id, err == nil
+	}
+	return primitive.NilObjectID, false
+}
+
+// MigrateKehadiranRefs mengubah user_id dan kegiatan_id kehadiran lama yang masih
+// tersimpan sebagai string hex menjadi ObjectID. Aman dijalankan berulang kali.
+func MigrateKehadiranRefs(db *mongo.Database) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	collection := db.Collection("kehadiran")
+	filter := bson.M{"$or": []bson.M{
+		{"user_id": bson.M{"$type": "string"}},
+		{"kegiatan_id": bson.M{"$type": "string"}},
+	}}
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		log.Println("Gagal migrasi kehadiran:", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var converted, merged, invalid int
+	now := time.Now()
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID         primitive.ObjectID `bson:"_id"`
+			UserID     interface{}        `bson:"user_id"`
+			KegiatanID interface{}        `bson:"kegiatan_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			log.Println("Gagal migrasi kehadiran:", err)
+			return
+		}
+		userID, okUser := toObjectID(doc.UserID)
+		kegiatanID, okKegiatan := toObjectID(doc.KegiatanID)
+		if !okUser || !okKegiatan {
+			log.Println("Kehadiran", doc.ID.Hex(), "memiliki referensi tidak valid, dilewati")
+			invalid++
+			continue
+		}
+		set := bson.M{"user_id": userID, "kegiatan_id": kegiatanID}
+		_, err := collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": set})
+		if mongo.IsDuplicateKeyError(err) {
+			// Kehadiran yang sama sudah tersimpan dengan ObjectID, data lama dipindahkan
+			// ke tempat sampah agar masih bisa diperiksa admin
+			set["deleted_at"] = WaktuHapusKehadiran(now, merged)
+			set["deleted_by"] = "migrasi"
+			_, err = collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": set})
+			merged++
+		}
+		if err != nil {
+			log.Println("Gagal migrasi kehadiran", doc.ID.Hex(), ":", err)
+			continue
+		}
+		converted++
+	}
+	if converted > 0 || invalid > 0 {
+		log.Println("Migrasi kehadiran:", converted, "dikonversi,", merged, "duplikat dipindah ke trash,", invalid, "tidak valid")
+	}
+}
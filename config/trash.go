@@ -0,0 +1,80 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TrashRetention membaca lama penyimpanan data terhapus dari TRASH_RETENTION_DAYS (default 30 hari)
+func TrashRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || days <= 0 {
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// WaktuHapusKehadiran mengembalikan deleted_at untuk kehadiran ke-urutan yang dipindah ke trash
+// dalam satu operasi. Index unik kehadiran (user_id, kegiatan_id, deleted_at) juga berlaku
+// untuk data di trash, sehingga beberapa duplikat dari pasangan yang sama diberi selisih 1 ms.
+func WaktuHapusKehadiran(now time.Time, urutan int) time.Time {
+	return now.Add(time.Duration(urutan) * time.Millisecond)
+}
+
+// Koleksi yang menyimpan kegiatan_id dan dihapus permanen bersama kegiatannya
+var kegiatanCascade = []string{
+	"kehadiran", "lpj", "anggaran", "panitia", "tugas", "feedback", "template_sertifikat", "sertifikat", "tamu",
+}
+
+// PurgeTrash menghapus permanen data yang sudah berada di tempat sampah melebihi masa retensi
+func PurgeTrash(db *mongo.Database, retention time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	expired := bson.M{"deleted_at": bson.M{"$lte": time.Now().Add(-retention)}}
+
+	// Data turunan kegiatan (kehadiran, LPJ, anggaran, panitia, dll) ikut dihapus bersama kegiatannya
+	cursor, err := db.Collection("kegiatan").Find(ctx, expired)
+	if err != nil {
+		return err
+	}
+	var kegiatans []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &kegiatans); err != nil {
+		return err
+	}
+	if len(kegiatans) > 0 {
+		kegiatanIDs := make([]primitive.ObjectID, 0, len(kegiatans))
+		for _, k := range kegiatans {
+			kegiatanIDs = append(kegiatanIDs, k.ID)
+		}
+		for _, collection := range kegiatanCascade {
+			res, err := db.Collection(collection).DeleteMany(ctx, bson.M{"kegiatan_id": bson.M{"$in": kegiatanIDs}})
+			if err != nil {
+				return err
+			}
+			if res.DeletedCount > 0 {
+				log.Println("Purge", collection, "milik kegiatan terhapus:", res.DeletedCount, "data dihapus permanen")
+			}
+		}
+	}
+
+	for _, collection := range []string{"kegiatan", "kehadiran", "kategori", "users"} {
+		res, err := db.Collection(collection).DeleteMany(ctx, expired)
+		if err != nil {
+			return err
+		}
+		if res.DeletedCount > 0 {
+			log.Println("Purge", collection, ":", res.DeletedCount, "data dihapus permanen")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"context"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Admin dengan UKM ini (default seeder) dapat mengelola semua UKM
+const semuaUKM = "Semua UKM"
+
+// currentUserObjectID mengubah claim "id" dari token menjadi ObjectID
+func currentUserObjectID(c *fiber.Ctx) (primitive.ObjectID, error) {
+	return primitive.ObjectIDFromHex(utils.GetUserID(c))
+}
+
+// findUserIDs mengambil _id semua user aktif yang cocok dengan filter
+func findUserIDs(ctx context.Context, filter bson.M) ([]primitive.ObjectID, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cursor, err := config.DB.Collection("users").Find(ctx, notDeleted(filter), opts)
+	if err != nil {
+		return nil, err
+	}
+	var users []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids, nil
+}
+
+// adminUKM mengembalikan UKM milik admin yang sedang login, kosong jika bukan admin
+func adminUKM(ctx context.Context, c *fiber.Ctx) (string, error) {
+	if utils.GetUserRole(c) != "admin" {
+		return "", nil
+	}
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return "", nil
+	}
+	var user model.User
+	err = config.DB.Collection("users").FindOne(ctx, notDeleted(bson.M{"_id": userID})).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+	return user.UKM, err
+}
+
+// canManageUKM memastikan user login adalah admin dari UKM yang dimaksud
+func canManageUKM(ctx context.Context, c *fiber.Ctx, ukm string) (bool, error) {
+	userUKM, err := adminUKM(ctx, c)
+	if err != nil || userUKM == "" {
+		return false, err
+	}
+	return userUKM == ukm || userUKM == semuaUKM, nil
+}
+
+// ukmPenyelenggara mengembalikan UKM tuan rumah utama diikuti co-host kegiatan
+func ukmPenyelenggara(kegiatan model.Kegiatan) []string {
+	hosts := []string{kegiatan.Kategori}
+	for _, ukm := range kegiatan.CoHosts {
+		if ukm != kegiatan.Kategori {
+			hosts = append(hosts, ukm)
+		}
+	}
+	return hosts
+}
+
+// canManageKegiatan mengizinkan admin dari UKM tuan rumah maupun co-host kegiatan
+func canManageKegiatan(ctx context.Context, c *fiber.Ctx, kegiatan model.Kegiatan) (bool, error) {
+	userUKM, err := adminUKM(ctx, c)
+	if err != nil || userUKM == "" {
+		return false, err
+	}
+	if userUKM == semuaUKM {
+		return true, nil
+	}
+	for _, ukm := range ukmPenyelenggara(kegiatan) {
+		if userUKM == ukm {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// filterKegiatanDikelola membatasi query kegiatan ke kegiatan yang diselenggarakan UKM admin
+// yang login (tuan rumah maupun co-host). Admin Semua UKM mendapat filter kosong, sedangkan
+// user yang bukan admin tidak mengelola kegiatan apa pun (ok=false).
+func filterKegiatanDikelola(ctx context.Context, c *fiber.Ctx) (bson.M, bool, error) {
+	userUKM, err := adminUKM(ctx, c)
+	if err != nil || userUKM == "" {
+		return nil, false, err
+	}
+	return filterPenyelenggara(userUKM), true, nil
+}
+
+// filterPenyelenggara membuat filter kegiatan yang diselenggarakan suatu UKM, Semua UKM tidak dibatasi
+func filterPenyelenggara(ukm string) bson.M {
+	if ukm == semuaUKM {
+		return bson.M{}
+	}
+	return bson.M{"$or": []bson.M{{"kategori": ukm}, {"co_hosts": ukm}}}
+}
+
+// pesertaKegiatan mengambil anggota yang terdaftar pada kegiatan:
+// anggota UKM tuan rumah dan co-host ditambah panitia kegiatan
+func pesertaKegiatan(ctx context.Context, kegiatan model.Kegiatan) ([]primitive.ObjectID, error) {
+	ids, err := findUserIDs(ctx, bson.M{"ukm": bson.M{"$in": ukmPenyelenggara(kegiatan)}})
+	if err != nil {
+		return nil, err
+	}
+	seen := map[primitive.ObjectID]bool{}
+	for _, id := range ids {
+		seen[id] = true
+	}
+	panitiaIDs, err := config.DB.Collection("panitia").Distinct(ctx, "user_id", bson.M{"kegiatan_id": kegiatan.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range panitiaIDs {
+		// Panitia yang juga anggota UKM penyelenggara tidak dihitung dua kali
+		if id, ok := raw.(primitive.ObjectID); ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,424 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var anggaranValidate = validator.New()
+
+type AnggaranResponse struct {
+	Items     []model.AnggaranItem `json:"items"`
+	Ringkasan RingkasanAnggaran    `json:"ringkasan"`
+}
+
+// RingkasanAnggaran membandingkan rencana dengan realisasi. Selisih positif berarti
+// pengeluaran lebih kecil dari rencana, saldo adalah pemasukan dikurangi pengeluaran.
+type RingkasanAnggaran struct {
+	TotalRencana        float64          `json:"total_rencana"`
+	TotalPengeluaran    float64          `json:"total_pengeluaran"`
+	TotalPemasukan      float64          `json:"total_pemasukan"`
+	Selisih             float64          `json:"selisih"`
+	PersentaseRealisasi float64          `json:"persentase_realisasi"`
+	Saldo               float64          `json:"saldo"`
+	PerKategori         []VarianKategori `json:"per_kategori"`
+}
+
+type VarianKategori struct {
+	Kategori  string  `json:"kategori"`
+	Rencana   float64 `json:"rencana"`
+	Realisasi float64 `json:"realisasi"`
+	Selisih   float64 `json:"selisih"`
+}
+
+type RingkasanAnggaranUKM struct {
+	UKM              string  `json:"ukm"`
+	JumlahKegiatan   int     `json:"jumlah_kegiatan"`
+	TotalRencana     float64 `json:"total_rencana"`
+	TotalPengeluaran float64 `json:"total_pengeluaran"`
+	TotalPemasukan   float64 `json:"total_pemasukan"`
+	Selisih          float64 `json:"selisih"`
+	Saldo            float64 `json:"saldo"`
+}
+
+// hitungRingkasanAnggaran menghitung total dan varians per kategori dari item anggaran
+func hitungRingkasanAnggaran(items []model.AnggaranItem) RingkasanAnggaran {
+	ringkasan := RingkasanAnggaran{PerKategori: []VarianKategori{}}
+	perKategori := map[string]*VarianKategori{}
+	order := []string{}
+	for _, item := range items {
+		switch item.Jenis {
+		case model.AnggaranPemasukan:
+			ringkasan.TotalPemasukan += item.Jumlah
+			continue
+		case model.AnggaranRencana:
+			ringkasan.TotalRencana += item.Jumlah
+		case model.AnggaranPengeluaran:
+			ringkasan.TotalPengeluaran += item.Jumlah
+		}
+		varian, ok := perKategori[item.Kategori]
+		if !ok {
+			varian = &VarianKategori{Kategori: item.Kategori}
+			perKategori[item.Kategori] = varian
+			order = append(order, item.Kategori)
+		}
+		if item.Jenis == model.AnggaranRencana {
+			varian.Rencana += item.Jumlah
+		} else {
+			varian.Realisasi += item.Jumlah
+		}
+	}
+	for _, kategori := range order {
+		varian := perKategori[kategori]
+		varian.Selisih = varian.Rencana - varian.Realisasi
+		ringkasan.PerKategori = append(ringkasan.PerKategori, *varian)
+	}
+	ringkasan.Selisih = ringkasan.TotalRencana - ringkasan.TotalPengeluaran
+	ringkasan.Saldo = ringkasan.TotalPemasukan - ringkasan.TotalPengeluaran
+	if ringkasan.TotalRencana > 0 {
+		ringkasan.PersentaseRealisasi = ringkasan.TotalPengeluaran / ringkasan.TotalRencana * 100
+	}
+	return ringkasan
+}
+
+// findAnggaranItems mengambil seluruh item anggaran sebuah kegiatan
+func findAnggaranItems(ctx context.Context, kegiatanID primitive.ObjectID) ([]model.AnggaranItem, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "jenis", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := config.DB.Collection("anggaran").Find(ctx, bson.M{"kegiatan_id": kegiatanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	items := []model.AnggaranItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetAnggaran godoc
+// @Summary Get budget of a kegiatan
+// @Description Daftar rencana, pengeluaran dan pemasukan beserta perhitungan varians
+// @Tags Anggaran
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} AnggaranResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/anggaran [get]
+// @Security BearerAuth
+func GetAnggaran(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	items, err := findAnggaranItems(ctx, kegiatan.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch anggaran"})
+	}
+	return c.JSON(AnggaranResponse{Items: items, Ringkasan: hitungRingkasanAnggaran(items)})
+}
+
+// CreateAnggaran godoc
+// @Summary Add a budget line to a kegiatan
+// @Description Hanya admin UKM tuan rumah utama yang dapat menambah anggaran, co-host hanya dapat melihat
+// @Tags Anggaran
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param anggaran body model.AnggaranItem true "Item anggaran"
+// @Success 201 {object} model.AnggaranItem
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/anggaran [post]
+// @Security BearerAuth
+func CreateAnggaran(c *fiber.Ctx) error {
+	var item model.AnggaranItem
+	if err := c.BodyParser(&item); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := anggaranValidate.Struct(item); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	// Anggaran dikelola UKM tuan rumah utama, co-host hanya bisa melihat
+	allowed, err := canManageUKM(ctx, c, kegiatan.Kategori)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the main hosting UKM can manage this budget"})
+	}
+
+	now := time.Now()
+	item.ID = primitive.NilObjectID
+	item.KegiatanID = kegiatan.ID
+	item.BuktiURL = ""
+	item.CreatedBy = utils.GetUserID(c)
+	item.CreatedAt = now
+	item.UpdatedBy = item.CreatedBy
+	item.UpdatedAt = now
+	res, err := config.DB.Collection("anggaran").InsertOne(ctx, item)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create anggaran"})
+	}
+	item.ID = res.InsertedID.(primitive.ObjectID)
+	return c.Status(201).JSON(item)
+}
+
+// UpdateAnggaran godoc
+// @Summary Update a budget line
+// @Tags Anggaran
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param itemId path string true "Anggaran item ID"
+// @Param anggaran body model.AnggaranItem true "Item anggaran"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/anggaran/{itemId} [put]
+// @Security BearerAuth
+func UpdateAnggaran(c *fiber.Ctx) error {
+	itemID, err := primitive.ObjectIDFromHex(c.Params("itemId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid item ID"})
+	}
+	var item model.AnggaranItem
+	if err := c.BodyParser(&item); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := anggaranValidate.Struct(item); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageUKM(ctx, c, kegiatan.Kategori)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the main hosting UKM can manage this budget"})
+	}
+
+	update := bson.M{
+		"jenis":      item.Jenis,
+		"kategori":   item.Kategori,
+		"uraian":     item.Uraian,
+		"jumlah":     item.Jumlah,
+		"tanggal":    item.Tanggal,
+		"updated_by": utils.GetUserID(c),
+		"updated_at": time.Now(),
+	}
+	res, err := config.DB.Collection("anggaran").UpdateOne(ctx, bson.M{"_id": itemID, "kegiatan_id": kegiatan.ID}, bson.M{"$set": update})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update anggaran"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Anggaran item not found"})
+	}
+	return c.JSON(fiber.Map{"message": "Anggaran updated"})
+}
+
+// DeleteAnggaran godoc
+// @Summary Delete a budget line
+// @Tags Anggaran
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param itemId path string true "Anggaran item ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/anggaran/{itemId} [delete]
+// @Security BearerAuth
+func DeleteAnggaran(c *fiber.Ctx) error {
+	itemID, err := primitive.ObjectIDFromHex(c.Params("itemId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid item ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageUKM(ctx, c, kegiatan.Kategori)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the main hosting UKM can manage this budget"})
+	}
+	res, err := config.DB.Collection("anggaran").DeleteOne(ctx, bson.M{"_id": itemID, "kegiatan_id": kegiatan.ID})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete anggaran"})
+	}
+	if res.DeletedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Anggaran item not found"})
+	}
+	return c.JSON(fiber.Map{"message": "Anggaran deleted"})
+}
+
+// UploadBuktiAnggaran godoc
+// @Summary Upload a receipt for a budget line
+// @Tags Anggaran
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param itemId path string true "Anggaran item ID"
+// @Param file formData file true "Bukti/kuitansi (pdf atau gambar, maks 10MB)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/anggaran/{itemId}/bukti [post]
+// @Security BearerAuth
+func UploadBuktiAnggaran(c *fiber.Ctx) error {
+	itemID, err := primitive.ObjectIDFromHex(c.Params("itemId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid item ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageUKM(ctx, c, kegiatan.Kategori)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the main hosting UKM can manage this budget"})
+	}
+	count, err := config.DB.Collection("anggaran").CountDocuments(ctx, bson.M{"_id": itemID, "kegiatan_id": kegiatan.ID})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch anggaran"})
+	}
+	if count == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Anggaran item not found"})
+	}
+	url, err := utils.SaveUpload(c, "file", "anggaran")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	update := bson.M{"bukti_url": url, "updated_by": utils.GetUserID(c), "updated_at": time.Now()}
+	_, err = config.DB.Collection("anggaran").UpdateOne(ctx, bson.M{"_id": itemID}, bson.M{"$set": update})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save bukti"})
+	}
+	return c.JSON(fiber.Map{"message": "Bukti uploaded", "bukti_url": url})
+}
+
+// GetRingkasanAnggaranUKM godoc
+// @Summary Budget summary per UKM
+// @Tags Anggaran
+// @Produce json
+// @Param ukm query string false "Filter nama UKM"
+// @Success 200 {array} RingkasanAnggaranUKM
+// @Failure 500 {object} map[string]interface{}
+// @Router /anggaran/ukm [get]
+// @Security BearerAuth
+func GetRingkasanAnggaranUKM(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	// Anggaran dari kegiatan yang sudah dihapus tidak ikut dihitung
+	kegiatanMatch := bson.M{"kegiatan.deleted_at": nil}
+	if ukm := c.Query("ukm"); ukm != "" {
+		kegiatanMatch["kegiatan.kategori"] = ukm
+	}
+	pipeline := []bson.M{
+		{
+			"$lookup": bson.M{
+				"from":         "kegiatan",
+				"localField":   "kegiatan_id",
+				"foreignField": "_id",
+				"as":           "kegiatan",
+			},
+		},
+		{"$unwind": "$kegiatan"},
+		{"$match": kegiatanMatch},
+		{
+			"$group": bson.M{
+				"_id":      bson.M{"ukm": "$kegiatan.kategori", "jenis": "$jenis"},
+				"total":    bson.M{"$sum": "$jumlah"},
+				"kegiatan": bson.M{"$addToSet": "$kegiatan_id"},
+			},
+		},
+	}
+	cursor, err := config.DB.Collection("anggaran").Aggregate(ctx, pipeline)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to aggregate anggaran"})
+	}
+	var results []struct {
+		ID struct {
+			UKM   string `bson:"ukm"`
+			Jenis string `bson:"jenis"`
+		} `bson:"_id"`
+		Total    float64              `bson:"total"`
+		Kegiatan []primitive.ObjectID `bson:"kegiatan"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode anggaran"})
+	}
+
+	perUKM := map[string]*RingkasanAnggaranUKM{}
+	kegiatanPerUKM := map[string]map[primitive.ObjectID]bool{}
+	for _, r := range results {
+		ringkasan, ok := perUKM[r.ID.UKM]
+		if !ok {
+			ringkasan = &RingkasanAnggaranUKM{UKM: r.ID.UKM}
+			perUKM[r.ID.UKM] = ringkasan
+			kegiatanPerUKM[r.ID.UKM] = map[primitive.ObjectID]bool{}
+		}
+		for _, id := range r.Kegiatan {
+			kegiatanPerUKM[r.ID.UKM][id] = true
+		}
+		switch r.ID.Jenis {
+		case model.AnggaranRencana:
+			ringkasan.TotalRencana = r.Total
+		case model.AnggaranPengeluaran:
+			ringkasan.TotalPengeluaran = r.Total
+		case model.AnggaranPemasukan:
+			ringkasan.TotalPemasukan = r.Total
+		}
+	}
+	summaries := make([]RingkasanAnggaranUKM, 0, len(perUKM))
+	for ukm, ringkasan := range perUKM {
+		ringkasan.JumlahKegiatan = len(kegiatanPerUKM[ukm])
+		ringkasan.Selisih = ringkasan.TotalRencana - ringkasan.TotalPengeluaran
+		ringkasan.Saldo = ringkasan.TotalPemasukan - ringkasan.TotalPengeluaran
+		summaries = append(summaries, *ringkasan)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].UKM < summaries[j].UKM })
+	return c.JSON(summaries)
+}
@@ -1,103 +1,112 @@
-package controller
-
-import (
-	"context"
-	"time"
-
-	"backend-sisteminformasi/config"
-	"backend-sisteminformasi/model"
-	"backend-sisteminformasi/utils"
-
-	"github.com/go-playground/validator/v10"
-	"github.com/gofiber/fiber/v2"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-)
-
-var validate = validator.New()
-
-// Register godoc
-// @Summary Register user
-// @Tags Auth
-// @Accept json
-// @Produce json
-// @Param user body model.User true "User Data"
-// @Success 201 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /register [post]
-func Register(c *fiber.Ctx) error {
-	var user model.User
-	if err := c.BodyParser(&user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
-	}
-	if err := validate.Struct(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	// Cek email unik
-	var existing model.User
-	err := config.DB.Collection("users").FindOne(ctx, bson.M{"email": user.Email}).Decode(&existing)
-	if err != mongo.ErrNoDocuments {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Email already registered"})
-	}
-	// Hash password
-	hash, err := utils.HashPassword(user.Password)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to hash password"})
-	}
-	user.Password = hash
-	// Validasi UKM harus ada di koleksi kategori
-	kategoriCount, err := config.DB.Collection("kategori").CountDocuments(ctx, bson.M{"nama_kategori": user.UKM})
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to validate UKM"})
-	}
-	if kategoriCount == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "UKM tidak valid, pilih dari daftar kategori"})
-	}
-	_, err = config.DB.Collection("users").InsertOne(ctx, user)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to register user"})
-	}
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "User registered successfully"})
-}
-
-// Login godoc
-// @Summary Login user
-// @Tags Auth
-// @Accept json
-// @Produce json
-// @Param credentials body map[string]string true "Login Data"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 401 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /login [post]
-func Login(c *fiber.Ctx) error {
-	var input struct {
-		Email    string `json:"email" validate:"required,email"`
-		Password string `json:"password" validate:"required,min=6"`
-	}
-	if err := c.BodyParser(&input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
-	}
-	if err := validate.Struct(input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	var user model.User
-	err := config.DB.Collection("users").FindOne(ctx, bson.M{"email": input.Email}).Decode(&user)
-	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid email or password"})
-	}
-	if !utils.CheckPasswordHash(input.Password, user.Password) {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid email or password"})
-	}
-	token, err := utils.GenerateJWT(user.ID, user.Email, user.Role)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to generate token"})
-	}
-	return c.JSON(fiber.Map{"token": token, "user": fiber.Map{"id": user.ID, "name": user.Nama, "email": user.Email, "role": user.Role, "ukm": user.UKM}})
-}
+package controller
+
+import (
+	"context"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var validate = validator.New()
+
+// Register godoc
+// @Summary Register user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param user body model.User true "User Data"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /register [post]
+func Register(c *fiber.Ctx) error {
+	var user model.User
+	if err := c.BodyParser(&user); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	// Registrasi mandiri selalu sebagai member, role lain hanya diberikan admin
+	user.Role = "member"
+	if err := validate.Struct(user); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Cek email unik
+	var existing model.User
+	err := config.DB.Collection("users").FindOne(ctx, bson.M{"email": user.Email}).Decode(&existing)
+	if err != mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Email already registered"})
+	}
+	// Hash password
+	hash, err := utils.HashPassword(user.Password)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to hash password"})
+	}
+	user.Password = hash
+	// Validasi UKM harus ada di koleksi kategori
+	kategoriCount, err := config.DB.Collection("kategori").CountDocuments(ctx, notDeleted(bson.M{"nama_kategori": user.UKM}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to validate UKM"})
+	}
+	if kategoriCount == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "UKM tidak valid, pilih dari daftar kategori"})
+	}
+	// Registrasi mandiri tidak memiliki pembuat, cukup catat waktunya
+	now := time.Now()
+	user.CreatedBy = ""
+	user.CreatedAt = now
+	user.UpdatedBy = ""
+	user.UpdatedAt = now
+	user.DeletedAt, user.DeletedBy = nil, ""
+	_, err = config.DB.Collection("users").InsertOne(ctx, user)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to register user"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "User registered successfully"})
+}
+
+// Login godoc
+// @Summary Login user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body map[string]string true "Login Data"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /login [post]
+func Login(c *fiber.Ctx) error {
+	var input struct {
+		Email    string `json:"email" validate:"required,email"`
+		Password string `json:"password" validate:"required,min=6"`
+	}
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validate.Struct(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var user model.User
+	err := config.DB.Collection("users").FindOne(ctx, notDeleted(bson.M{"email": input.Email})).Decode(&user)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid email or password"})
+	}
+	if !utils.CheckPasswordHash(input.Password, user.Password) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid email or password"})
+	}
+	token, err := utils.GenerateJWT(user.ID, user.Email, user.Role)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to generate token"})
+	}
+	return c.JSON(fiber.Map{"token": token, "user": fiber.Map{"id": user.ID, "name": user.Nama, "email": user.Email, "role": user.Role, "ukm": user.UKM}})
+}
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/skip2/go-qrcode"
+)
+
+var checkinValidate = validator.New()
+
+type CheckinQRResponse struct {
+	Token         string    `json:"token"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	IntervalDetik int       `json:"interval_detik"`
+}
+
+type CheckinRequest struct {
+	Token     string           `json:"token" validate:"required"`
+	LokasiCek *model.LokasiCek `json:"lokasi_cek"`
+}
+
+const defaultRadiusMeter = 100
+
+// Jendela check-in jika panitia tidak mengaturnya
+var defaultJendelaCheckin = model.JendelaCheckin{
+	BukaSebelumMenit:        60,
+	TutupSetelahMenit:       0,
+	ToleransiTerlambatMenit: 15,
+}
+
+// isTanggalSaja mengecek apakah tanggal kegiatan tidak menyertakan jam
+func isTanggalSaja(tanggal string) bool {
+	return len(strings.TrimSpace(tanggal)) == len("2006-01-02")
+}
+
+// jadwalKegiatan mengembalikan waktu mulai dan selesai kegiatan. Tanpa tanggal_selesai
+// kegiatan dianggap selesai di akhir hari yang sama.
+func jadwalKegiatan(kegiatan model.Kegiatan) (time.Time, time.Time, error) {
+	mulai, err := utils.ParseTanggal(kegiatan.Tanggal)
+	if err != nil {
+		return mulai, mulai, err
+	}
+	selesai := time.Date(mulai.Year(), mulai.Month(), mulai.Day()+1, 0, 0, 0, 0, utils.Location)
+	if t, err := utils.ParseTanggal(kegiatan.TanggalSelesai); err == nil {
+		selesai = t
+		if isTanggalSaja(kegiatan.TanggalSelesai) {
+			selesai = selesai.AddDate(0, 0, 1)
+		}
+	}
+	return mulai, selesai, nil
+}
+
+func jendelaCheckin(kegiatan model.Kegiatan) model.JendelaCheckin {
+	if kegiatan.JendelaCheckin != nil {
+		return *kegiatan.JendelaCheckin
+	}
+	return defaultJendelaCheckin
+}
+
+// checkinDitutup mengecek apakah jendela check-in kegiatan sudah berakhir
+func checkinDitutup(kegiatan model.Kegiatan, now time.Time) bool {
+	_, selesai, err := jadwalKegiatan(kegiatan)
+	if err != nil {
+		return false
+	}
+	return now.After(selesai.Add(time.Duration(jendelaCheckin(kegiatan).TutupSetelahMenit) * time.Minute))
+}
+
+// statusCheckin menentukan status check-in mandiri dari jadwal kegiatan: hadir, atau
+// terlambat setelah jam mulai + toleransi. Pesan tidak kosong berarti check-in di luar
+// jendela dan harus ditolak. Kegiatan tanpa jam mulai tidak memakai status terlambat.
+func statusCheckin(kegiatan model.Kegiatan, now time.Time) (string, string) {
+	mulai, selesai, err := jadwalKegiatan(kegiatan)
+	if err != nil {
+		// Data lama dengan format tanggal tidak dikenal tidak dibatasi
+		return model.StatusHadir, ""
+	}
+	jendela := jendelaCheckin(kegiatan)
+	buka := mulai.Add(-time.Duration(jendela.BukaSebelumMenit) * time.Minute)
+	tutup := selesai.Add(time.Duration(jendela.TutupSetelahMenit) * time.Minute)
+	format := func(t time.Time) string {
+		return t.In(utils.Location).Format("15:04") + " " + utils.FormatTanggalIndonesia(t)
+	}
+	if now.Before(buka) {
+		return "", fmt.Sprintf("Check-in opens at %s", format(buka))
+	}
+	if now.After(tutup) {
+		return "", fmt.Sprintf("Check-in closed at %s", format(tutup))
+	}
+	batas := mulai.Add(time.Duration(jendela.ToleransiTerlambatMenit) * time.Minute)
+	if !isTanggalSaja(kegiatan.Tanggal) && now.After(batas) {
+		return model.StatusTerlambat, ""
+	}
+	return model.StatusHadir, ""
+}
+
+// terapkanGeofence menghitung jarak perangkat ke lokasi kegiatan. Check-in di luar radius
+// tetap disimpan tetapi ditandai pending untuk diverifikasi admin. Pesan tidak kosong
+// berarti request ditolak.
+func terapkanGeofence(kegiatan model.Kegiatan, kehadiran *model.Kehadiran) string {
+	if kegiatan.Latitude == nil || kegiatan.Longitude == nil {
+		return ""
+	}
+	lokasi := kehadiran.LokasiCek
+	if lokasi == nil {
+		return "Device location (lokasi_cek) is required to check in to this kegiatan"
+	}
+	radius := float64(kegiatan.RadiusMeter)
+	if radius == 0 {
+		radius = defaultRadiusMeter
+	}
+	lokasi.JarakMeter = math.Round(utils.JarakMeter(*kegiatan.Latitude, *kegiatan.Longitude, lokasi.Latitude, lokasi.Longitude))
+	// Akurasi GPS memberi toleransi, tetapi tidak lebih dari radius itu sendiri
+	if lokasi.JarakMeter-math.Min(lokasi.Akurasi, radius) > radius {
+		kehadiran.Verifikasi = model.VerifikasiPending
+	}
+	return ""
+}
+
+// GetCheckinQR godoc
+// @Summary Get the rotating check-in QR code of a kegiatan
+// @Description Ditampilkan panitia di lokasi. Kode berganti setiap CHECKIN_QR_INTERVAL detik, gunakan format=png untuk gambar QR.
+// @Tags Check-in
+// @Produce json
+// @Produce png
+// @Param id path string true "Kegiatan ID"
+// @Param format query string false "Isi png untuk mendapatkan gambar QR"
+// @Success 200 {object} CheckinQRResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/checkin-qr [get]
+// @Security BearerAuth
+func GetCheckinQR(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only organizers can display the check-in code"})
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(409).JSON(fiber.Map{"error": "Kegiatan is not approved yet"})
+	}
+
+	token, expiresAt, err := utils.GenerateCheckinToken(kegiatan.ID.Hex(), time.Now())
+	if err != nil {
+		log.Println("Gagal membuat kode check-in:", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Check-in code is not configured on the server"})
+	}
+	// Kode berganti terus, jangan sampai disimpan cache
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	if c.Query("format") == "png" {
+		png, err := qrcode.Encode(token, qrcode.Medium, 512)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to generate QR code"})
+		}
+		c.Type("png")
+		return c.Send(png)
+	}
+	return c.JSON(CheckinQRResponse{
+		Token:         token,
+		ExpiresAt:     expiresAt,
+		IntervalDetik: int(utils.CheckinInterval().Seconds()),
+	})
+}
+
+// CheckinKegiatan godoc
+// @Summary Check in to a kegiatan by scanning its QR code
+// @Description Kehadiran dicatat untuk user yang login, hanya jika kode QR masih berlaku untuk kegiatan tersebut.
+// @Description Jika kegiatan memiliki koordinat, lokasi_cek wajib dikirim dan check-in di luar radius ditandai untuk diverifikasi admin.
+// @Description Check-in hanya diterima dalam jendela check-in kegiatan, setelah jam mulai + toleransi status menjadi terlambat.
+// @Tags Check-in
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param body body CheckinRequest true "Kode hasil scan QR"
+// @Success 201 {object} model.Kehadiran
+// @Success 200 {object} model.Kehadiran
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/checkin [post]
+// @Security BearerAuth
+func CheckinKegiatan(c *fiber.Ctx) error {
+	var input CheckinRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := checkinValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	return checkinMandiri(c, c.Params("id"), input)
+}
+
+// checkinMandiri mencatat kehadiran user yang login setelah kode QR diverifikasi.
+// Dipakai oleh CheckinKegiatan dan check-in mandiri lewat POST /kehadiran.
+func checkinMandiri(c *fiber.Ctx, kegiatanID string, input CheckinRequest) error {
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid token"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, kegiatanID)
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+	}
+	now := time.Now()
+	if !utils.VerifyCheckinToken(input.Token, kegiatan.ID.Hex(), now) {
+		return c.Status(400).JSON(fiber.Map{"error": "Check-in code is invalid or expired, please scan again"})
+	}
+	status, msg := statusCheckin(kegiatan, now)
+	if msg != "" {
+		return c.Status(409).JSON(fiber.Map{"error": msg})
+	}
+
+	kehadiran := model.Kehadiran{
+		UserID:     userID,
+		KegiatanID: kegiatan.ID,
+		Status:     status,
+		WaktuCek:   now.In(utils.Location).Format(time.RFC3339),
+		Metode:     model.MetodeQR,
+		LokasiCek:  input.LokasiCek,
+		CreatedBy:  userID.Hex(),
+		CreatedAt:  now,
+		UpdatedBy:  userID.Hex(),
+		UpdatedAt:  now,
+	}
+	if msg := terapkanGeofence(kegiatan, &kehadiran); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	kehadiran, created, err := simpanKehadiran(ctx, kehadiran)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create kehadiran"})
+	}
+	if !created {
+		return c.JSON(kehadiran)
+	}
+	return c.Status(201).JSON(kehadiran)
+}
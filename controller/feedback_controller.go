@@ -0,0 +1,345 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var feedbackValidate = validator.New()
+
+type FeedbackRequest struct {
+	Rating   int    `json:"rating" validate:"required,min=1,max=5"`
+	Komentar string `json:"komentar" validate:"max=2000"`
+	Anonim   bool   `json:"anonim"`
+}
+
+// FeedbackResponse tidak menyertakan identitas pengirim jika feedback anonim
+type FeedbackResponse struct {
+	ID        primitive.ObjectID `json:"id"`
+	UserID    string             `json:"user_id,omitempty"`
+	UserNama  string             `json:"user_nama,omitempty"`
+	Rating    int                `json:"rating"`
+	Komentar  string             `json:"komentar"`
+	Anonim    bool               `json:"anonim"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type RingkasanFeedback struct {
+	JumlahFeedback int64            `json:"jumlah_feedback"`
+	RataRata       float64          `json:"rata_rata"`
+	Distribusi     map[string]int64 `json:"distribusi"`
+}
+
+type RingkasanFeedbackUKM struct {
+	UKM            string `json:"ukm"`
+	JumlahKegiatan int    `json:"jumlah_kegiatan"`
+	RingkasanFeedback
+}
+
+type FeedbackListResponse struct {
+	Ringkasan RingkasanFeedback  `json:"ringkasan"`
+	Feedback  []FeedbackResponse `json:"feedback"`
+}
+
+func newRingkasanFeedback() RingkasanFeedback {
+	distribusi := map[string]int64{}
+	for rating := 1; rating <= 5; rating++ {
+		distribusi[strconv.Itoa(rating)] = 0
+	}
+	return RingkasanFeedback{Distribusi: distribusi}
+}
+
+// tambahRating menambahkan sejumlah rating ke ringkasan dan memperbarui rata-rata
+func tambahRating(r *RingkasanFeedback, rating int, jumlah int64) {
+	if jumlah == 0 {
+		return
+	}
+	total := r.RataRata*float64(r.JumlahFeedback) + float64(rating)*float64(jumlah)
+	r.JumlahFeedback += jumlah
+	r.RataRata = total / float64(r.JumlahFeedback)
+	r.Distribusi[strconv.Itoa(rating)] += jumlah
+}
+
+// hitungRingkasanFeedback menghitung ringkasan rating satu kegiatan
+func hitungRingkasanFeedback(ctx context.Context, kegiatanID primitive.ObjectID) (RingkasanFeedback, error) {
+	ringkasan := newRingkasanFeedback()
+	pipeline := []bson.M{
+		{"$match": bson.M{"kegiatan_id": kegiatanID}},
+		{"$group": bson.M{"_id": "$rating", "jumlah": bson.M{"$sum": 1}}},
+	}
+	cursor, err := config.DB.Collection("feedback").Aggregate(ctx, pipeline)
+	if err != nil {
+		return ringkasan, err
+	}
+	var results []struct {
+		Rating int   `bson:"_id"`
+		Jumlah int64 `bson:"jumlah"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return ringkasan, err
+	}
+	for _, r := range results {
+		tambahRating(&ringkasan, r.Rating, r.Jumlah)
+	}
+	return ringkasan, nil
+}
+
+// hitungFeedbackPerUKM menghitung ringkasan rating per UKM penyelenggara.
+// Feedback dari kegiatan yang sudah dihapus tidak ikut dihitung.
+func hitungFeedbackPerUKM(ctx context.Context, ukm string) ([]RingkasanFeedbackUKM, error) {
+	kegiatanMatch := bson.M{"kegiatan.deleted_at": nil}
+	if ukm != "" {
+		kegiatanMatch["kegiatan.kategori"] = ukm
+	}
+	pipeline := []bson.M{
+		{
+			"$lookup": bson.M{
+				"from":         "kegiatan",
+				"localField":   "kegiatan_id",
+				"foreignField": "_id",
+				"as":           "kegiatan",
+			},
+		},
+		{"$unwind": "$kegiatan"},
+		{"$match": kegiatanMatch},
+		{
+			"$group": bson.M{
+				"_id":      bson.M{"ukm": "$kegiatan.kategori", "rating": "$rating"},
+				"jumlah":   bson.M{"$sum": 1},
+				"kegiatan": bson.M{"$addToSet": "$kegiatan_id"},
+			},
+		},
+	}
+	cursor, err := config.DB.Collection("feedback").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		ID struct {
+			UKM    string `bson:"ukm"`
+			Rating int    `bson:"rating"`
+		} `bson:"_id"`
+		Jumlah   int64                `bson:"jumlah"`
+		Kegiatan []primitive.ObjectID `bson:"kegiatan"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	perUKM := map[string]*RingkasanFeedbackUKM{}
+	kegiatanPerUKM := map[string]map[primitive.ObjectID]bool{}
+	for _, r := range results {
+		ringkasan, ok := perUKM[r.ID.UKM]
+		if !ok {
+			ringkasan = &RingkasanFeedbackUKM{UKM: r.ID.UKM, RingkasanFeedback: newRingkasanFeedback()}
+			perUKM[r.ID.UKM] = ringkasan
+			kegiatanPerUKM[r.ID.UKM] = map[primitive.ObjectID]bool{}
+		}
+		for _, id := range r.Kegiatan {
+			kegiatanPerUKM[r.ID.UKM][id] = true
+		}
+		tambahRating(&ringkasan.RingkasanFeedback, r.ID.Rating, r.Jumlah)
+	}
+	summaries := make([]RingkasanFeedbackUKM, 0, len(perUKM))
+	for ukm, ringkasan := range perUKM {
+		ringkasan.JumlahKegiatan = len(kegiatanPerUKM[ukm])
+		summaries = append(summaries, *ringkasan)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].UKM < summaries[j].UKM })
+	return summaries, nil
+}
+
+// CreateFeedback godoc
+// @Summary Submit feedback for a kegiatan
+// @Description Hanya anggota yang tercatat hadir pada kegiatan yang dapat memberi penilaian, satu kali per kegiatan
+// @Tags Feedback
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param feedback body FeedbackRequest true "Rating 1-5 dan komentar"
+// @Success 201 {object} FeedbackResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/feedback [post]
+// @Security BearerAuth
+func CreateFeedback(c *fiber.Ctx) error {
+	var input FeedbackRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := feedbackValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+
+	count, err := config.DB.Collection("kehadiran").CountDocuments(ctx, notDeleted(filterHadir(bson.M{
+		"user_id":     userID,
+		"kegiatan_id": kegiatan.ID,
+	})))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check kehadiran"})
+	}
+	if count == 0 {
+		return c.Status(403).JSON(fiber.Map{"error": "Only members who attended this kegiatan can give feedback"})
+	}
+
+	now := time.Now()
+	feedback := model.Feedback{
+		KegiatanID: kegiatan.ID,
+		UserID:     userID,
+		Rating:     input.Rating,
+		Komentar:   input.Komentar,
+		Anonim:     input.Anonim,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	res, err := config.DB.Collection("feedback").InsertOne(ctx, feedback)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Feedback already submitted for this kegiatan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save feedback"})
+	}
+	return c.Status(201).JSON(FeedbackResponse{
+		ID:        res.InsertedID.(primitive.ObjectID),
+		UserID:    userID.Hex(),
+		Rating:    feedback.Rating,
+		Komentar:  feedback.Komentar,
+		Anonim:    feedback.Anonim,
+		CreatedAt: feedback.CreatedAt,
+	})
+}
+
+// GetFeedback godoc
+// @Summary Get feedback of a kegiatan
+// @Description Identitas pengirim feedback anonim tidak ditampilkan
+// @Tags Feedback
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} FeedbackListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/feedback [get]
+// @Security BearerAuth
+func GetFeedback(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	pipeline := []bson.M{
+		{"$match": bson.M{"kegiatan_id": kegiatan.ID}},
+		{"$sort": bson.M{"created_at": -1}},
+		{
+			"$lookup": bson.M{
+				"from":         "users",
+				"localField":   "user_id",
+				"foreignField": "_id",
+				"as":           "user_data",
+			},
+		},
+		{
+			"$addFields": bson.M{
+				"user_nama": bson.M{"$arrayElemAt": []interface{}{"$user_data.nama", 0}},
+			},
+		},
+	}
+	cursor, err := config.DB.Collection("feedback").Aggregate(ctx, pipeline)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch feedback"})
+	}
+	var results []struct {
+		model.Feedback `bson:",inline"`
+		UserNama       string `bson:"user_nama"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode feedback"})
+	}
+
+	response := FeedbackListResponse{Ringkasan: newRingkasanFeedback(), Feedback: []FeedbackResponse{}}
+	for _, r := range results {
+		item := FeedbackResponse{
+			ID:        r.ID,
+			Rating:    r.Rating,
+			Komentar:  r.Komentar,
+			Anonim:    r.Anonim,
+			CreatedAt: r.CreatedAt,
+		}
+		if !r.Anonim {
+			item.UserID = r.UserID.Hex()
+			item.UserNama = r.UserNama
+		}
+		response.Feedback = append(response.Feedback, item)
+		tambahRating(&response.Ringkasan, r.Rating, 1)
+	}
+	return c.JSON(response)
+}
+
+// GetRingkasanFeedback godoc
+// @Summary Get aggregated rating of a kegiatan
+// @Tags Feedback
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} RingkasanFeedback
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/feedback/ringkasan [get]
+// @Security BearerAuth
+func GetRingkasanFeedback(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	ringkasan, err := hitungRingkasanFeedback(ctx, kegiatan.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to aggregate feedback"})
+	}
+	return c.JSON(ringkasan)
+}
+
+// GetRingkasanFeedbackUKM godoc
+// @Summary Get aggregated ratings per UKM
+// @Tags Feedback
+// @Produce json
+// @Param ukm query string false "Filter UKM"
+// @Success 200 {array} RingkasanFeedbackUKM
+// @Failure 500 {object} map[string]interface{}
+// @Router /feedback/ukm [get]
+// @Security BearerAuth
+func GetRingkasanFeedbackUKM(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	summaries, err := hitungFeedbackPerUKM(ctx, c.Query("ukm"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to aggregate feedback"})
+	}
+	return c.JSON(summaries)
+}
@@ -0,0 +1,725 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/scheduler"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	JobImportData = "import_data"
+
+	maxImportRows    = 5000
+	maxImportSize    = 10 * 1024 * 1024
+	importBatchSize  = 100
+	importRunTimeout = 4 * time.Minute
+)
+
+// Kolom yang dikenali untuk setiap jenis import, sesuai nama field JSON model
+var importKolom = map[string][]string{
+	"kegiatan":  {"judul", "deskripsi", "tanggal", "lokasi", "kategori", "maxParticipants", "dokumentasi_url"},
+	"users":     {"nama", "email", "password", "role", "ukm"},
+	"kehadiran": {"user_id", "email", "kegiatan_id", "status", "waktu_cek"},
+}
+
+// importValidate memakai nama field JSON pada pesan error agar sesuai header file
+var importValidate = func() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.Split(f.Tag.Get("json"), ",")[0]
+	})
+	return v
+}()
+
+type ImportReport struct {
+	Jenis        string                 `json:"jenis"`
+	DryRun       bool                   `json:"dry_run"`
+	TotalBaris   int                    `json:"total_baris"`
+	BarisValid   int                    `json:"baris_valid"`
+	BarisInvalid int                    `json:"baris_invalid"`
+	Errors       []model.ImportRowError `json:"errors"`
+	ImportID     string                 `json:"import_id,omitempty"`
+}
+
+// importLookup berisi data referensi yang dimuat sekali per import. ukm adalah UKM admin
+// yang mengimport, kegiatan hanya berisi kegiatan yang diselenggarakan UKM tersebut.
+type importLookup struct {
+	ukm      string
+	kategori map[string]bool
+	emails   map[string]string // email -> user id hex
+	userIDs  map[string]bool
+	kegiatan map[string]model.Kegiatan
+}
+
+func formatValidationError(err error) string {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err.Error()
+	}
+	msgs := make([]string, 0, len(verrs))
+	for _, fe := range verrs {
+		if fe.Param() != "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s=%s", fe.Field(), fe.Tag(), fe.Param()))
+		} else {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", fe.Field(), fe.Tag()))
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// tableToRows mengubah tabel mentah menjadi baris bernama berdasarkan header.
+// Nama header dicocokkan tanpa membedakan huruf besar/kecil.
+func tableToRows(jenis string, table [][]string) ([]model.ImportRow, error) {
+	if len(table) == 0 {
+		return nil, errors.New("File is empty")
+	}
+	canonical := map[string]string{}
+	for _, kolom := range importKolom[jenis] {
+		canonical[strings.ToLower(kolom)] = kolom
+	}
+	header := make([]string, len(table[0]))
+	known := 0
+	for i, h := range table[0] {
+		key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(h), " ", "_"))
+		if kolom, ok := canonical[key]; ok {
+			header[i] = kolom
+			known++
+		}
+	}
+	if known == 0 {
+		return nil, errors.New("No recognized columns, expected: " + strings.Join(importKolom[jenis], ", "))
+	}
+
+	rows := []model.ImportRow{}
+	for i, cells := range table[1:] {
+		data := map[string]string{}
+		empty := true
+		for j, cell := range cells {
+			if j >= len(header) || header[j] == "" {
+				continue
+			}
+			cell = strings.TrimSpace(cell)
+			if cell != "" {
+				empty = false
+			}
+			data[header[j]] = cell
+		}
+		if empty {
+			continue
+		}
+		rows = append(rows, model.ImportRow{Baris: i + 2, Data: data})
+	}
+	if len(rows) > maxImportRows {
+		return nil, fmt.Errorf("Too many rows (max %d)", maxImportRows)
+	}
+	return rows, nil
+}
+
+// normalizeTanggal menerima tanggal teks maupun nomor seri tanggal Excel
+func normalizeTanggal(value string) (string, error) {
+	if t, ok := utils.ExcelSerialToTime(value); ok {
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+			return t.Format("2006-01-02"), nil
+		}
+		return t.Format("2006-01-02T15:04:05"), nil
+	}
+	if _, err := utils.ParseTanggal(value); err != nil {
+		return value, errors.New("tanggal: invalid date format")
+	}
+	return value, nil
+}
+
+func rowToKegiatan(data map[string]string) (model.Kegiatan, error) {
+	kegiatan := model.Kegiatan{
+		Judul:          data["judul"],
+		Deskripsi:      data["deskripsi"],
+		Lokasi:         data["lokasi"],
+		Kategori:       data["kategori"],
+		DokumentasiURL: data["dokumentasi_url"],
+		ApprovalStatus: model.KegiatanDraft,
+	}
+	if v := data["maxParticipants"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return kegiatan, errors.New("maxParticipants: must be a number")
+		}
+		kegiatan.MaxParticipants = n
+	}
+	if v := data["tanggal"]; v != "" {
+		tanggal, err := normalizeTanggal(v)
+		if err != nil {
+			return kegiatan, err
+		}
+		kegiatan.Tanggal = tanggal
+	}
+	return kegiatan, nil
+}
+
+func rowToUser(data map[string]string) model.User {
+	user := model.User{
+		Nama:     data["nama"],
+		Email:    strings.ToLower(data["email"]),
+		Password: data["password"],
+		Role:     strings.ToLower(data["role"]),
+		UKM:      data["ukm"],
+	}
+	if user.Role == "" {
+		user.Role = "member"
+	}
+	return user
+}
+
+// rowToKehadiran mengisi user_id dari kolom email jika user_id kosong
+// ID yang bukan ObjectID valid dibiarkan kosong sehingga gagal di validasi required
+func rowToKehadiran(data map[string]string, lookup importLookup) model.Kehadiran {
+	userID := data["user_id"]
+	if userID == "" && data["email"] != "" {
+		userID = lookup.emails[strings.ToLower(data["email"])]
+	}
+	kehadiran := model.Kehadiran{
+		Status:   strings.ToLower(data["status"]),
+		WaktuCek: data["waktu_cek"],
+		Metode:   model.MetodeImport,
+	}
+	if waktu, err := normalizeTanggal(kehadiran.WaktuCek); err == nil {
+		kehadiran.WaktuCek = waktu
+	}
+	kehadiran.UserID, _ = primitive.ObjectIDFromHex(userID)
+	kehadiran.KegiatanID, _ = primitive.ObjectIDFromHex(data["kegiatan_id"])
+	return kehadiran
+}
+
+// validateWaktuImport memastikan kehadiran hadir/terlambat punya waktu_cek di dalam
+// jendela check-in kegiatan, sama seperti check-in mandiri dan sinkronisasi offline
+func validateWaktuImport(kegiatan model.Kegiatan, kehadiran model.Kehadiran) string {
+	if !isCheckin(kehadiran.Status) {
+		return ""
+	}
+	if kehadiran.WaktuCek == "" {
+		return "waktu_cek: required for hadir and terlambat"
+	}
+	waktu, err := utils.ParseTanggal(kehadiran.WaktuCek)
+	if err != nil {
+		return "waktu_cek: invalid date format"
+	}
+	if _, msg := statusCheckin(kegiatan, waktu); msg != "" {
+		return "waktu_cek: outside the check-in window, " + msg
+	}
+	return ""
+}
+
+func distinctStrings(ctx context.Context, collection, field string, filter bson.M) (map[string]bool, error) {
+	values, err := config.DB.Collection(collection).Distinct(ctx, field, filter)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			set[v] = true
+		case primitive.ObjectID:
+			set[v.Hex()] = true
+		}
+	}
+	return set, nil
+}
+
+func loadImportLookup(ctx context.Context, jenis, ukm string) (importLookup, error) {
+	lookup := importLookup{ukm: ukm}
+	var err error
+	switch jenis {
+	case "kegiatan":
+		if lookup.kategori, err = distinctStrings(ctx, "kategori", "nama_kategori", notDeleted(bson.M{})); err != nil {
+			return lookup, err
+		}
+	case "users":
+		if lookup.kategori, err = distinctStrings(ctx, "kategori", "nama_kategori", notDeleted(bson.M{})); err != nil {
+			return lookup, err
+		}
+		fallthrough
+	case "kehadiran":
+		// Email yang sudah terhapus tetap dianggap terpakai, sama seperti Register
+		filter := bson.M{}
+		if jenis == "kehadiran" {
+			filter = notDeleted(filter)
+		}
+		opts := options.Find().SetProjection(bson.M{"_id": 1, "email": 1})
+		cursor, err := config.DB.Collection("users").Find(ctx, filter, opts)
+		if err != nil {
+			return lookup, err
+		}
+		var users []struct {
+			ID    primitive.ObjectID `bson:"_id"`
+			Email string             `bson:"email"`
+		}
+		if err := cursor.All(ctx, &users); err != nil {
+			return lookup, err
+		}
+		lookup.emails = make(map[string]string, len(users))
+		lookup.userIDs = make(map[string]bool, len(users))
+		for _, u := range users {
+			lookup.emails[strings.ToLower(u.Email)] = u.ID.Hex()
+			lookup.userIDs[u.ID.Hex()] = true
+		}
+		if jenis == "kehadiran" {
+			cursor, err := config.DB.Collection("kegiatan").Find(ctx, notDeleted(filterPenyelenggara(ukm)))
+			if err != nil {
+				return lookup, err
+			}
+			var kegiatan []model.Kegiatan
+			if err := cursor.All(ctx, &kegiatan); err != nil {
+				return lookup, err
+			}
+			lookup.kegiatan = make(map[string]model.Kegiatan, len(kegiatan))
+			for _, k := range kegiatan {
+				lookup.kegiatan[k.ID.Hex()] = k
+			}
+		}
+	}
+	return lookup, nil
+}
+
+// validateImportRows memvalidasi setiap baris dengan aturan validate pada model
+// ditambah pengecekan referensi dan duplikasi di dalam file
+func validateImportRows(jenis string, rows []model.ImportRow, lookup importLookup) ([]model.ImportRow, []model.ImportRowError) {
+	valid := []model.ImportRow{}
+	rowErrors := []model.ImportRowError{}
+	seen := map[string]int{}
+	for _, row := range rows {
+		var msg string
+		switch jenis {
+		case "kegiatan":
+			kegiatan, err := rowToKegiatan(row.Data)
+			if err != nil {
+				msg = err.Error()
+			} else if err := importValidate.Struct(kegiatan); err != nil {
+				msg = formatValidationError(err)
+			} else if !lookup.kategori[kegiatan.Kategori] {
+				msg = "kategori: not found in kategori"
+			} else if lookup.ukm != semuaUKM && kegiatan.Kategori != lookup.ukm {
+				msg = "kategori: you can only import kegiatan for your own UKM"
+			}
+		case "users":
+			user := rowToUser(row.Data)
+			if err := importValidate.Struct(user); err != nil {
+				msg = formatValidationError(err)
+			} else if lookup.emails[user.Email] != "" {
+				msg = "email: already registered"
+			} else if first, dup := seen[user.Email]; dup {
+				msg = fmt.Sprintf("email: duplicate of row %d", first)
+			} else if !lookup.kategori[user.UKM] {
+				msg = "ukm: not found in kategori"
+			} else {
+				seen[user.Email] = row.Baris
+			}
+		case "kehadiran":
+			kehadiran := rowToKehadiran(row.Data, lookup)
+			key := kehadiran.UserID.Hex() + ":" + kehadiran.KegiatanID.Hex()
+			if err := importValidate.Struct(kehadiran); err != nil {
+				msg = formatValidationError(err)
+			} else if !lookup.userIDs[kehadiran.UserID.Hex()] {
+				msg = "user_id: user not found"
+			} else if kegiatan, ok := lookup.kegiatan[kehadiran.KegiatanID.Hex()]; !ok {
+				msg = "kegiatan_id: kegiatan not found or not hosted by your UKM"
+			} else if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+				msg = "kegiatan_id: kegiatan is not approved"
+			} else if pesan := validateWaktuImport(kegiatan, kehadiran); pesan != "" {
+				msg = pesan
+			} else if first, dup := seen[key]; dup {
+				msg = fmt.Sprintf("duplicate of row %d", first)
+			} else {
+				seen[key] = row.Baris
+			}
+		}
+		if msg != "" {
+			rowErrors = append(rowErrors, model.ImportRowError{Baris: row.Baris, Pesan: msg})
+			continue
+		}
+		valid = append(valid, row)
+	}
+	return valid, rowErrors
+}
+
+// denganID mengubah dokumen import menjadi bson.M dengan _id yang sudah ditetapkan.
+// ID pada model User dan Kehadiran bertipe string sehingga tidak bisa diisi langsung.
+func denganID(doc interface{}, id primitive.ObjectID) (bson.M, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var m bson.M
+	if err := bson.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	m["_id"] = id
+	return m, nil
+}
+
+// idTersimpan mengecek _id mana saja yang sudah ada di koleksi
+func idTersimpan(ctx context.Context, collection string, ids []primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	values, err := config.DB.Collection(collection).Distinct(ctx, "_id", bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[primitive.ObjectID]bool, len(values))
+	for _, v := range values {
+		if id, ok := v.(primitive.ObjectID); ok {
+			result[id] = true
+		}
+	}
+	return result, nil
+}
+
+// hashImportPasswords mengganti password setiap baris import users dengan hash bcrypt
+// agar password asli tidak pernah tersimpan di import_jobs
+func hashImportPasswords(rows []model.ImportRow) error {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		hashErr error
+	)
+	antrian := make(chan int)
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range antrian {
+				hash, err := utils.HashPassword(rows[i].Data["password"])
+				if err != nil {
+					mu.Lock()
+					hashErr = err
+					mu.Unlock()
+					continue
+				}
+				rows[i].Data["password"] = hash
+			}
+		}()
+	}
+	for i := range rows {
+		antrian <- i
+	}
+	close(antrian)
+	wg.Wait()
+	return hashErr
+}
+
+// buildImportDoc membuat dokumen siap simpan dari baris yang sudah valid
+func buildImportDoc(jenis string, data map[string]string, lookup importLookup, createdBy string, now time.Time) (interface{}, error) {
+	switch jenis {
+	case "kegiatan":
+		kegiatan, err := rowToKegiatan(data)
+		kegiatan.CreatedBy, kegiatan.CreatedAt = createdBy, now
+		kegiatan.UpdatedBy, kegiatan.UpdatedAt = createdBy, now
+		return kegiatan, err
+	case "users":
+		// Password sudah di-hash oleh hashImportPasswords sebelum baris disimpan
+		user := rowToUser(data)
+		user.CreatedBy, user.CreatedAt = createdBy, now
+		user.UpdatedBy, user.UpdatedAt = createdBy, now
+		return user, nil
+	case "kehadiran":
+		kehadiran := rowToKehadiran(data, lookup)
+		kehadiran.CreatedBy, kehadiran.CreatedAt = createdBy, now
+		kehadiran.UpdatedBy, kehadiran.UpdatedAt = createdBy, now
+		return kehadiran, nil
+	}
+	return nil, errors.New("unknown import type")
+}
+
+// prosesImport menjalankan import dan menandainya gagal ketika percobaan job sudah habis.
+// Baris yang tersisa ikut dihapus agar data import tidak tertinggal di import_jobs.
+func prosesImport(ctx context.Context, job model.Job) error {
+	importID, err := primitive.ObjectIDFromHex(fmt.Sprint(job.Payload["import_id"]))
+	if err != nil {
+		return nil
+	}
+	err = jalankanImport(ctx, importID, job)
+	if err != nil && job.Attempts >= job.MaxAttempts {
+		// ctx job bisa sudah habis, gunakan context baru untuk mencatat kegagalan
+		failCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		now := time.Now()
+		_, updateErr := config.DB.Collection("import_jobs").UpdateOne(failCtx, bson.M{"_id": importID}, bson.M{
+			"$set":   bson.M{"status": model.JobFailed, "finished_at": now, "updated_at": now},
+			"$unset": bson.M{"rows": ""},
+		})
+		if updateErr != nil {
+			log.Println("Gagal menandai import", importID.Hex(), "sebagai gagal:", updateErr)
+		}
+	}
+	return err
+}
+
+// jalankanImport menyimpan baris valid per batch dan mencatat progres setelah setiap batch.
+// Jika waktu habis, sisa baris dilanjutkan oleh job baru.
+func jalankanImport(ctx context.Context, importID primitive.ObjectID, job model.Job) error {
+	collection := config.DB.Collection("import_jobs")
+	var imp model.ImportJob
+	if err := collection.FindOne(ctx, bson.M{"_id": importID}).Decode(&imp); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		return err
+	}
+	if imp.Status == model.JobDone {
+		return nil
+	}
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": importID}, bson.M{"$set": bson.M{"status": model.JobRunning, "updated_at": time.Now()}}); err != nil {
+		return err
+	}
+	lookup, err := loadImportLookup(ctx, imp.Jenis, imp.UKM)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(importRunTimeout)
+	for start := imp.Diproses; start < len(imp.Rows); start += importBatchSize {
+		if time.Now().After(deadline) {
+			return scheduler.Schedule(context.Background(), JobImportData, time.Now(), job.Payload)
+		}
+		end := start + importBatchSize
+		if end > len(imp.Rows) {
+			end = len(imp.Rows)
+		}
+		batch := imp.Rows[start:end]
+		now := time.Now()
+		docs := make([]interface{}, 0, len(batch))
+		rowsDoc := make([]model.ImportRow, 0, len(batch))
+		rowErrors := []model.ImportRowError{}
+		for _, row := range batch {
+			// Import lama yang dibuat sebelum ID ditetapkan mendapat ID baru
+			if row.ID.IsZero() {
+				row.ID = primitive.NewObjectID()
+			}
+			doc, err := buildImportDoc(imp.Jenis, row.Data, lookup, imp.CreatedBy, now)
+			if err == nil {
+				doc, err = denganID(doc, row.ID)
+			}
+			if err != nil {
+				rowErrors = append(rowErrors, model.ImportRowError{Baris: row.Baris, Pesan: err.Error()})
+				continue
+			}
+			docs = append(docs, doc)
+			rowsDoc = append(rowsDoc, row)
+		}
+		berhasil := len(docs)
+		if len(docs) > 0 {
+			_, err := config.DB.Collection(imp.Jenis).InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+			var bulkErr mongo.BulkWriteException
+			if errors.As(err, &bulkErr) {
+				// Batch yang diulang setelah gagal mencatat progres bentrok dengan _id miliknya
+				// sendiri, baris tersebut sudah tersimpan dan dihitung berhasil
+				duplikat := []primitive.ObjectID{}
+				for _, we := range bulkErr.WriteErrors {
+					if mongo.IsDuplicateKeyError(we) {
+						duplikat = append(duplikat, rowsDoc[we.Index].ID)
+					}
+				}
+				tersimpan := map[primitive.ObjectID]bool{}
+				if len(duplikat) > 0 {
+					if tersimpan, err = idTersimpan(ctx, imp.Jenis, duplikat); err != nil {
+						return err
+					}
+				}
+				for _, we := range bulkErr.WriteErrors {
+					if tersimpan[rowsDoc[we.Index].ID] {
+						continue
+					}
+					rowErrors = append(rowErrors, model.ImportRowError{Baris: rowsDoc[we.Index].Baris, Pesan: "failed to save: " + we.Message})
+					berhasil--
+				}
+			} else if err != nil {
+				return err
+			}
+		}
+		update := bson.M{
+			"$inc":  bson.M{"diproses": len(batch), "berhasil": berhasil, "gagal": len(batch) - berhasil},
+			"$push": bson.M{"errors": bson.M{"$each": rowErrors}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": importID}, update); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": importID}, bson.M{
+		"$set":   bson.M{"status": model.JobDone, "finished_at": now, "updated_at": now},
+		"$unset": bson.M{"rows": ""},
+	})
+	return err
+}
+
+// ImportData godoc
+// @Summary Import kegiatan, users or kehadiran from CSV/XLSX
+// @Description Baris pertama file adalah header dengan nama field JSON model.
+// @Description kegiatan: judul, deskripsi, tanggal, lokasi, kategori, maxParticipants, dokumentasi_url. Kategori harus UKM admin yang mengimport.
+// @Description users: nama, email, password, role (default member), ukm.
+// @Description kehadiran: user_id atau email, kegiatan_id, status, waktu_cek. Kegiatan harus approved dan diselenggarakan UKM admin,
+// @Description waktu_cek hadir/terlambat harus berada di jendela check-in kegiatan.
+// @Description Dengan dry_run=true hanya laporan validasi yang dikembalikan. Tanpa dry_run, baris valid disimpan
+// @Description di background dan progres dapat dicek di /import/{id}.
+// @Tags Import
+// @Accept multipart/form-data
+// @Produce json
+// @Param jenis path string true "Jenis data (kegiatan, users, kehadiran)"
+// @Param dry_run query bool false "Hanya validasi tanpa menyimpan"
+// @Param file formData file true "File CSV atau XLSX"
+// @Success 200 {object} ImportReport
+// @Success 202 {object} ImportReport
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /import/{jenis} [post]
+// @Security BearerAuth
+func ImportData(c *fiber.Ctx) error {
+	jenis := c.Params("jenis")
+	if _, ok := importKolom[jenis]; !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid import type"})
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "File is required"})
+	}
+	if file.Size > maxImportSize {
+		return c.Status(400).JSON(fiber.Map{"error": "File too large (max 10MB)"})
+	}
+	f, err := file.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Failed to read file"})
+	}
+	defer f.Close()
+	content, err := io.ReadAll(io.LimitReader(f, maxImportSize))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Failed to read file"})
+	}
+	table, err := utils.ReadTable(file.Filename, content)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	rows, err := tableToRows(jenis, table)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	ukm, err := adminUKM(ctx, c)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	lookup, err := loadImportLookup(ctx, jenis, ukm)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load reference data"})
+	}
+	valid, rowErrors := validateImportRows(jenis, rows, lookup)
+	report := ImportReport{
+		Jenis:        jenis,
+		DryRun:       c.QueryBool("dry_run"),
+		TotalBaris:   len(rows),
+		BarisValid:   len(valid),
+		BarisInvalid: len(rowErrors),
+		Errors:       rowErrors,
+	}
+	if report.DryRun {
+		return c.JSON(report)
+	}
+	if len(valid) == 0 {
+		return c.Status(400).JSON(report)
+	}
+	for i := range valid {
+		valid[i].ID = primitive.NewObjectID()
+	}
+	if jenis == "users" {
+		if err := hashImportPasswords(valid); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to hash passwords"})
+		}
+	}
+
+	now := time.Now()
+	imp := model.ImportJob{
+		Jenis:      jenis,
+		NamaFile:   file.Filename,
+		Status:     model.JobPending,
+		TotalBaris: len(rows),
+		BarisValid: len(valid),
+		Errors:     []model.ImportRowError{},
+		Rows:       valid,
+		UKM:        ukm,
+		CreatedBy:  utils.GetUserID(c),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	res, err := config.DB.Collection("import_jobs").InsertOne(ctx, imp)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create import job"})
+	}
+	importID := res.InsertedID.(primitive.ObjectID)
+	if err := scheduler.Schedule(ctx, JobImportData, now, map[string]interface{}{"import_id": importID.Hex()}); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to schedule import job"})
+	}
+	// Jalankan segera tanpa menunggu polling scheduler berikutnya
+	go scheduler.RunDue()
+
+	report.ImportID = importID.Hex()
+	return c.Status(202).JSON(report)
+}
+
+// GetImportJob godoc
+// @Summary Get import progress
+// @Description Hanya dapat dilihat admin yang mengimport dan admin lain dari UKM yang sama
+// @Tags Import
+// @Produce json
+// @Param id path string true "Import ID"
+// @Success 200 {object} model.ImportJob
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /import/{id} [get]
+// @Security BearerAuth
+func GetImportJob(c *fiber.Ctx) error {
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid import ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ukm, err := adminUKM(ctx, c)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	filter := bson.M{"_id": objID}
+	if ukm != semuaUKM {
+		// Import milik UKM lain dilaporkan tidak ditemukan
+		filter["$or"] = []bson.M{{"created_by": utils.GetUserID(c)}, {"ukm": ukm}}
+	}
+	var imp model.ImportJob
+	opts := options.FindOne().SetProjection(bson.M{"rows": 0})
+	if err := config.DB.Collection("import_jobs").FindOne(ctx, filter, opts).Decode(&imp); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Import not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch import"})
+	}
+	return c.JSON(imp)
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"backend-sisteminformasi/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// tabelNama membuat tabel dengan header nama dan n baris data
+func tabelNama(n int) [][]string {
+	table := [][]string{{"nama"}}
+	for i := 0; i < n; i++ {
+		table = append(table, []string{"Anggota"})
+	}
+	return table
+}
+
+// barisNama adalah hasil tableToRows yang diharapkan dari tabelNama(n)
+func barisNama(n int) []model.ImportRow {
+	rows := make([]model.ImportRow, 0, n)
+	for i := 0; i < n; i++ {
+		rows = append(rows, model.ImportRow{Baris: i + 2, Data: map[string]string{"nama": "Anggota"}})
+	}
+	return rows
+}
+
+func TestTableToRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		jenis   string
+		table   [][]string
+		want    []model.ImportRow
+		wantErr bool
+	}{
+		{
+			name:    "tabel kosong",
+			jenis:   "users",
+			table:   nil,
+			wantErr: true,
+		},
+		{
+			name:    "tidak ada kolom dikenali",
+			jenis:   "users",
+			table:   [][]string{{"foo", "bar"}, {"1", "2"}},
+			wantErr: true,
+		},
+		{
+			name:  "header tanpa membedakan huruf dan spasi",
+			jenis: "kegiatan",
+			table: [][]string{
+				{" Judul ", "MaxParticipants", "Dokumentasi URL", "catatan"},
+				{"Rapat", "20", "https://example.com", "diabaikan"},
+			},
+			want: []model.ImportRow{
+				{Baris: 2, Data: map[string]string{"judul": "Rapat", "maxParticipants": "20", "dokumentasi_url": "https://example.com"}},
+			},
+		},
+		{
+			name:  "baris kosong dilewati dan nomor baris mengikuti file",
+			jenis: "users",
+			table: [][]string{
+				{"nama", "email"},
+				{"  ", ""},
+				{"Budi", " budi@example.com ", "kolom lebih"},
+				{"Sari"},
+			},
+			want: []model.ImportRow{
+				{Baris: 3, Data: map[string]string{"nama": "Budi", "email": "budi@example.com"}},
+				{Baris: 4, Data: map[string]string{"nama": "Sari"}},
+			},
+		},
+		{
+			name:  "hanya header",
+			jenis: "kehadiran",
+			table: [][]string{{"user_id", "kegiatan_id", "status"}},
+			want:  []model.ImportRow{},
+		},
+		{
+			name:    "melebihi batas baris",
+			jenis:   "users",
+			table:   tabelNama(maxImportRows + 1),
+			wantErr: true,
+		},
+		{
+			name:  "tepat batas baris",
+			jenis: "users",
+			table: tabelNama(maxImportRows),
+			want:  barisNama(maxImportRows),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tableToRows(tt.jenis, tt.table)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("tableToRows() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("tableToRows() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tableToRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateImportRowsScope(t *testing.T) {
+	approved := model.Kegiatan{ID: primitive.NewObjectID(), Tanggal: "2025-03-01T09:00:00", Kategori: "UKM Musik", ApprovalStatus: model.KegiatanApproved}
+	draft := model.Kegiatan{ID: primitive.NewObjectID(), Tanggal: "2025-03-01", Kategori: "UKM Musik", ApprovalStatus: model.KegiatanDraft}
+	userID := primitive.NewObjectID().Hex()
+	lookup := importLookup{
+		ukm:      "UKM Musik",
+		kategori: map[string]bool{"UKM Musik": true, "UKM Tari": true},
+		userIDs:  map[string]bool{userID: true},
+		kegiatan: map[string]model.Kegiatan{approved.ID.Hex(): approved, draft.ID.Hex(): draft},
+	}
+	tests := []struct {
+		name    string
+		jenis   string
+		data    map[string]string
+		wantErr string
+	}{
+		{"kegiatan UKM sendiri", "kegiatan", map[string]string{"judul": "Latihan", "tanggal": "2025-03-01", "kategori": "UKM Musik"}, ""},
+		{"kegiatan UKM lain", "kegiatan", map[string]string{"judul": "Latihan", "tanggal": "2025-03-01", "kategori": "UKM Tari"}, "kategori: you can only import kegiatan for your own UKM"},
+		{"kegiatan kategori tidak ada", "kegiatan", map[string]string{"judul": "Latihan", "tanggal": "2025-03-01", "kategori": "UKM Catur"}, "kategori: not found in kategori"},
+		{"kehadiran di jendela", "kehadiran", map[string]string{"user_id": userID, "kegiatan_id": approved.ID.Hex(), "status": "hadir", "waktu_cek": "2025-03-01T08:30:00"}, ""},
+		{"kehadiran di luar jendela", "kehadiran", map[string]string{"user_id": userID, "kegiatan_id": approved.ID.Hex(), "status": "hadir", "waktu_cek": "2025-03-02T10:00:00"}, "waktu_cek: outside the check-in window, Check-in closed at 00:00 2 Maret 2025"},
+		{"kehadiran tanpa waktu", "kehadiran", map[string]string{"user_id": userID, "kegiatan_id": approved.ID.Hex(), "status": "terlambat"}, "waktu_cek: required for hadir and terlambat"},
+		{"kehadiran izin tanpa waktu", "kehadiran", map[string]string{"user_id": userID, "kegiatan_id": approved.ID.Hex(), "status": "izin"}, ""},
+		{"kehadiran kegiatan draft", "kehadiran", map[string]string{"user_id": userID, "kegiatan_id": draft.ID.Hex(), "status": "izin"}, "kegiatan_id: kegiatan is not approved"},
+		{"kehadiran kegiatan UKM lain", "kehadiran", map[string]string{"user_id": userID, "kegiatan_id": primitive.NewObjectID().Hex(), "status": "izin"}, "kegiatan_id: kegiatan not found or not hosted by your UKM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, rowErrors := validateImportRows(tt.jenis, []model.ImportRow{{Baris: 2, Data: tt.data}}, lookup)
+			got := ""
+			if len(rowErrors) > 0 {
+				got = rowErrors[0].Pesan
+			}
+			if got != tt.wantErr {
+				t.Errorf("validateImportRows() error = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
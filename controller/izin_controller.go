@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Izin masih dapat diajukan sampai 7 hari setelah kegiatan selesai
+const batasPengajuanIzin = 7 * 24 * time.Hour
+
+type AjukanIzinRequest struct {
+	Status string `json:"status" form:"status" validate:"required,oneof=izin sakit"`
+	Alasan string `json:"alasan" form:"alasan" validate:"required,max=1000"`
+}
+
+// AjukanIzin godoc
+// @Summary Submit an excuse (izin/sakit) for a kegiatan
+// @Description Dapat diajukan sebelum kegiatan atau setelahnya (maks. 7 hari setelah selesai), termasuk untuk mengganti status alpa.
+// @Description Dokumen pendukung (mis. surat dokter) opsional. Pengajuan menunggu persetujuan admin lewat PUT /kehadiran/{id}/verifikasi.
+// @Tags Kehadiran
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param status formData string true "izin atau sakit"
+// @Param alasan formData string true "Alasan tidak hadir"
+// @Param dokumen formData file false "Dokumen pendukung"
+// @Success 201 {object} model.Kehadiran
+// @Success 200 {object} model.Kehadiran
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/izin [post]
+// @Security BearerAuth
+func AjukanIzin(c *fiber.Ctx) error {
+	var input AjukanIzinRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := kehadiranValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	userID := utils.GetUserID(c)
+	userObjID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid token"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err == nil && approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		err = mongo.ErrNoDocuments
+	}
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	now := time.Now()
+	if _, selesai, err := jadwalKegiatan(kegiatan); err == nil && now.After(selesai.Add(batasPengajuanIzin)) {
+		return c.Status(409).JSON(fiber.Map{"error": "The deadline for submitting an excuse has passed"})
+	}
+
+	var existing model.Kehadiran
+	filter := notDeleted(bson.M{"user_id": userObjID, "kegiatan_id": kegiatan.ID})
+	err = config.DB.Collection("kehadiran").FindOne(ctx, filter).Decode(&existing)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check kehadiran"})
+	}
+	found := err == nil
+	if found {
+		switch {
+		case existing.Status == model.StatusHadir || existing.Status == model.StatusTerlambat:
+			return c.Status(409).JSON(fiber.Map{"error": "Already checked in to this kegiatan"})
+		case existing.Verifikasi == model.VerifikasiApproved && (existing.Status == model.StatusIzin || existing.Status == model.StatusSakit):
+			return c.Status(409).JSON(fiber.Map{"error": "Excuse has already been approved"})
+		}
+	}
+
+	dokumenURL := existing.DokumenURL
+	if _, err := c.FormFile("dokumen"); err == nil {
+		if dokumenURL, err = utils.SaveUpload(c, "dokumen", "izin"); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+	}
+
+	if !found {
+		kehadiran := model.Kehadiran{
+			UserID:     userObjID,
+			KegiatanID: kegiatan.ID,
+			Status:     input.Status,
+			Metode:     model.MetodeSelf,
+			Alasan:     input.Alasan,
+			DokumenURL: dokumenURL,
+			Verifikasi: model.VerifikasiPending,
+			CreatedBy:  userID,
+			CreatedAt:  now,
+			UpdatedBy:  userID,
+			UpdatedAt:  now,
+		}
+		res, err := config.DB.Collection("kehadiran").InsertOne(ctx, kehadiran)
+		if mongo.IsDuplicateKeyError(err) {
+			// Check-in atau pengajuan lain tersimpan lebih dulu
+			return c.Status(409).JSON(fiber.Map{"error": "Kehadiran was recorded by another request, please retry"})
+		}
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to submit excuse"})
+		}
+		kehadiran.ID = res.InsertedID.(primitive.ObjectID).Hex()
+		notifyPengajuanIzin(ctx, kegiatan)
+		return c.Status(201).JSON(kehadiran)
+	}
+
+	// Pengajuan setelah alpa atau revisi pengajuan sebelumnya menimpa data yang ada
+	statusLama := existing.Status
+	existing.Status = input.Status
+	existing.Alasan = input.Alasan
+	existing.DokumenURL = dokumenURL
+	existing.Verifikasi = model.VerifikasiPending
+	existing.CatatanVerifikasi, existing.VerifiedBy, existing.VerifiedAt = "", "", nil
+	existing.UpdatedBy = userID
+	existing.UpdatedAt = now
+	objID, _ := primitive.ObjectIDFromHex(existing.ID)
+	update := bson.M{
+		"$set": bson.M{
+			"status":      existing.Status,
+			"alasan":      existing.Alasan,
+			"dokumen_url": existing.DokumenURL,
+			"verifikasi":  existing.Verifikasi,
+			"updated_by":  existing.UpdatedBy,
+			"updated_at":  existing.UpdatedAt,
+		},
+		"$unset": bson.M{"catatan_verifikasi": "", "verified_by": "", "verified_at": ""},
+	}
+	// Filter status lama mencegah izin menimpa check-in yang masuk di antaranya
+	res, err := config.DB.Collection("kehadiran").UpdateOne(ctx, notDeleted(bson.M{"_id": objID, "status": statusLama}), update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to submit excuse"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "Kehadiran has changed, please retry"})
+	}
+	notifyPengajuanIzin(ctx, kegiatan)
+	return c.JSON(existing)
+}
+
+// notifyPengajuanIzin memberi tahu admin UKM penyelenggara bahwa ada izin yang perlu diperiksa
+func notifyPengajuanIzin(ctx context.Context, kegiatan model.Kegiatan) {
+	admins, err := findUserIDs(ctx, bson.M{"role": "admin", "ukm": bson.M{"$in": ukmPenyelenggara(kegiatan)}})
+	if err != nil {
+		return
+	}
+	notify(ctx, admins, "izin_submitted", "Pengajuan izin baru",
+		fmt.Sprintf("Ada pengajuan izin untuk kegiatan \"%s\" yang menunggu persetujuan", kegiatan.Judul), kegiatan.ID)
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/scheduler"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Tipe job yang dijalankan scheduler
+const (
+	JobPurgeTrash         = "purge_trash"
+	JobJadwalkanPengingat = "jadwalkan_pengingat"
+	JobPengingatKegiatan  = "pengingat_kegiatan"
+)
+
+const sweepPengingatInterval = 10 * time.Minute
+
+// Pengingat dikirim 24 jam dan 1 jam sebelum kegiatan dimulai
+var jadwalPengingat = []struct {
+	Kode    string
+	Sebelum time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"1h", time.Hour},
+}
+
+// RegisterJobs mendaftarkan handler job dan membuat job berulang jika belum ada
+func RegisterJobs() {
+	scheduler.Register(JobPurgeTrash, func(ctx context.Context, job model.Job) error {
+		return config.PurgeTrash(config.DB, config.TrashRetention())
+	})
+	scheduler.Register(JobJadwalkanPengingat, jadwalkanPengingat)
+	scheduler.Register(JobPengingatKegiatan, kirimPengingat)
+	scheduler.Register(JobImportData, prosesImport)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := scheduler.EnsureRecurring(ctx, JobPurgeTrash, JobPurgeTrash, 24*time.Hour); err != nil {
+		log.Println("Gagal menjadwalkan purge tempat sampah:", err)
+	}
+	if err := scheduler.EnsureRecurring(ctx, JobJadwalkanPengingat, JobJadwalkanPengingat, sweepPengingatInterval); err != nil {
+		log.Println("Gagal menjadwalkan pengingat kegiatan:", err)
+	}
+}
+
+// jadwalkanPengingat membuat job pengingat untuk kegiatan approved yang akan datang.
+// Jika tanggal kegiatan diubah, job pengingat ikut dijadwalkan ulang.
+func jadwalkanPengingat(ctx context.Context, job model.Job) error {
+	now := time.Now()
+	// Tanggal disimpan sebagai string ISO, cukup ambil yang tidak lebih awal dari kemarin
+	kemarin := now.In(utils.Location).AddDate(0, 0, -1).Format("2006-01-02")
+	filter := approvedOnly(notDeleted(bson.M{"tanggal": bson.M{"$gte": kemarin}}))
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "tanggal": 1})
+	cursor, err := config.DB.Collection("kegiatan").Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	var kegiatans []model.Kegiatan
+	if err := cursor.All(ctx, &kegiatans); err != nil {
+		return err
+	}
+	for _, kegiatan := range kegiatans {
+		mulai, err := utils.ParseTanggal(kegiatan.Tanggal)
+		if err != nil || !mulai.After(now) {
+			continue
+		}
+		for _, p := range jadwalPengingat {
+			runAt := mulai.Add(-p.Sebelum)
+			// Lewati pengingat yang waktunya sudah lama terlewat (mis. kegiatan baru disetujui)
+			if runAt.Before(now.Add(-sweepPengingatInterval)) {
+				continue
+			}
+			key := "pengingat:" + kegiatan.ID.Hex() + ":" + p.Kode
+			payload := map[string]interface{}{
+				"kegiatan_id": kegiatan.ID.Hex(),
+				"tanggal":     kegiatan.Tanggal,
+			}
+			if err := scheduler.ScheduleUnique(ctx, key, JobPengingatKegiatan, runAt, payload); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// kirimPengingat mengirim notifikasi ke anggota terdaftar. Kegiatan yang sudah
+// dihapus, dibatalkan persetujuannya, atau berubah tanggal dilewati.
+func kirimPengingat(ctx context.Context, job model.Job) error {
+	kegiatanID, _ := job.Payload["kegiatan_id"].(string)
+	tanggal, _ := job.Payload["tanggal"].(string)
+	kegiatan, err := findKegiatanByID(ctx, kegiatanID)
+	if err == mongo.ErrNoDocuments || err == errInvalidID {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved || kegiatan.Tanggal != tanggal {
+		return nil
+	}
+	mulai, err := utils.ParseTanggal(kegiatan.Tanggal)
+	if err != nil {
+		return nil
+	}
+	userIDs, err := pesertaKegiatan(ctx, kegiatan)
+	if err != nil {
+		return err
+	}
+	waktu := utils.FormatTanggalIndonesia(mulai) + " pukul " + mulai.In(utils.Location).Format("15:04")
+	pesan := fmt.Sprintf("Kegiatan \"%s\" akan dimulai %s", kegiatan.Judul, waktu)
+	if kegiatan.Lokasi != "" {
+		pesan += " di " + kegiatan.Lokasi
+	}
+	notify(ctx, userIDs, JobPengingatKegiatan, "Pengingat kegiatan", pesan, kegiatan.ID)
+	return nil
+}
+
+// GetJobs godoc
+// @Summary Get background jobs
+// @Description Menampilkan 100 job terbaru untuk pemantauan scheduler
+// @Tags Jobs
+// @Produce json
+// @Param status query string false "Filter status (pending, running, done, failed)"
+// @Param tipe query string false "Filter tipe job"
+// @Success 200 {array} model.Job
+// @Failure 500 {object} map[string]interface{}
+// @Router /jobs [get]
+// @Security BearerAuth
+func GetJobs(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filter := bson.M{}
+	if status := c.Query("status"); status != "" {
+		filter["status"] = status
+	}
+	if tipe := c.Query("tipe"); tipe != "" {
+		filter["tipe"] = tipe
+	}
+	opts := options.Find().SetSort(bson.M{"run_at": -1}).SetLimit(100)
+	cursor, err := config.DB.Collection("jobs").Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch jobs"})
+	}
+	jobs := []model.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode jobs"})
+	}
+	return c.JSON(jobs)
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ReviewKegiatanRequest struct {
+	Status   string `json:"status" validate:"required,oneof=approved rejected"`
+	Komentar string `json:"komentar" validate:"required_if=Status rejected"`
+}
+
+// approvalStatusOf mengembalikan status pengajuan, kegiatan lama dianggap sudah approved
+func approvalStatusOf(kegiatan model.Kegiatan) string {
+	if kegiatan.ApprovalStatus == "" {
+		return model.KegiatanApproved
+	}
+	return kegiatan.ApprovalStatus
+}
+
+// approvedOnly menambahkan syarat kegiatan sudah disetujui (termasuk data lama tanpa status)
+func approvedOnly(filter bson.M) bson.M {
+	filter["approval_status"] = bson.M{"$in": []interface{}{model.KegiatanApproved, nil}}
+	return filter
+}
+
+// perubahanSubstansial mengecek apakah perubahan kegiatan menyentuh isi yang dinilai reviewer
+func perubahanSubstansial(lama, baru model.Kegiatan) bool {
+	return lama.Judul != baru.Judul ||
+		lama.Deskripsi != baru.Deskripsi ||
+		lama.Tanggal != baru.Tanggal ||
+		lama.TanggalSelesai != baru.TanggalSelesai ||
+		lama.Lokasi != baru.Lokasi ||
+		lama.Publik != baru.Publik ||
+		lama.MaxParticipants != baru.MaxParticipants ||
+		hostsChanged(lama, baru)
+}
+
+// terapkanStatusEdit menyesuaikan status pengajuan saat kegiatan diedit. Kegiatan yang sedang
+// direview tidak boleh diubah isinya, sedangkan kegiatan yang sudah disetujui kembali ke draft
+// dan harus diajukan ulang. Pesan tidak kosong berarti edit ditolak.
+func terapkanStatusEdit(lama, baru model.Kegiatan, update bson.M) string {
+	if !perubahanSubstansial(lama, baru) {
+		return ""
+	}
+	switch approvalStatusOf(lama) {
+	case model.KegiatanSubmitted:
+		return "Kegiatan is under review, only documentation and check-in settings can be changed"
+	case model.KegiatanApproved:
+		update["approval_status"] = model.KegiatanDraft
+	}
+	return ""
+}
+
+// filterStatusLama mencocokkan kegiatan dengan status pengajuan saat dibaca, agar edit tidak
+// menimpa pengajuan atau review yang terjadi di antaranya
+func filterStatusLama(objID primitive.ObjectID, lama model.Kegiatan) bson.M {
+	var status interface{}
+	if lama.ApprovalStatus != "" {
+		status = lama.ApprovalStatus
+	}
+	return notDeleted(bson.M{"_id": objID, "approval_status": status})
+}
+
+// SubmitKegiatan godoc
+// @Summary Submit kegiatan proposal for review
+// @Description Mengubah status draft/rejected menjadi submitted dan memberi notifikasi ke reviewer. Hanya admin UKM penyelenggara kegiatan.
+// @Tags Kegiatan Approval
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} KegiatanResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/submit [post]
+// @Security BearerAuth
+func SubmitKegiatan(c *fiber.Ctx) error {
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var kegiatan model.Kegiatan
+	err = config.DB.Collection("kegiatan").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&kegiatan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can submit this kegiatan"})
+	}
+	status := approvalStatusOf(kegiatan)
+	if status != model.KegiatanDraft && status != model.KegiatanRejected {
+		return c.Status(409).JSON(fiber.Map{"error": "Only draft or rejected kegiatan can be submitted"})
+	}
+
+	kegiatan.ApprovalStatus = model.KegiatanSubmitted
+	kegiatan.UpdatedBy = utils.GetUserID(c)
+	kegiatan.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"approval_status": kegiatan.ApprovalStatus,
+		"updated_by":      kegiatan.UpdatedBy,
+		"updated_at":      kegiatan.UpdatedAt,
+	}}
+	// Filter status lama mencegah perubahan ganda dari request bersamaan
+	filter := notDeleted(bson.M{"_id": objID, "approval_status": status})
+	res, err := config.DB.Collection("kegiatan").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to submit kegiatan"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "Kegiatan status has changed, please reload"})
+	}
+
+	reviewers, err := findUserIDs(ctx, bson.M{"role": "reviewer"})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch reviewers"})
+	}
+	notify(ctx, reviewers, "kegiatan_submitted", "Pengajuan kegiatan baru",
+		fmt.Sprintf("Kegiatan \"%s\" (%s) menunggu persetujuan", kegiatan.Judul, kegiatan.Kategori), objID)
+
+	return c.JSON(toKegiatanResponse(kegiatan))
+}
+
+// ReviewKegiatan godoc
+// @Summary Approve or reject a submitted kegiatan
+// @Description Komentar wajib diisi saat menolak. Pembuat kegiatan akan menerima notifikasi.
+// @Tags Kegiatan Approval
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param review body ReviewKegiatanRequest true "Keputusan reviewer"
+// @Success 200 {object} KegiatanResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/review [post]
+// @Security BearerAuth
+func ReviewKegiatan(c *fiber.Ctx) error {
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	var input ReviewKegiatanRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := kegiatanValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var kegiatan model.Kegiatan
+	err = config.DB.Collection("kegiatan").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&kegiatan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanSubmitted {
+		return c.Status(409).JSON(fiber.Map{"error": "Only submitted kegiatan can be reviewed"})
+	}
+
+	now := time.Now()
+	review := model.KegiatanReview{
+		ReviewerID: utils.GetUserID(c),
+		Status:     input.Status,
+		Komentar:   input.Komentar,
+		CreatedAt:  now,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"approval_status": input.Status,
+			"updated_by":      review.ReviewerID,
+			"updated_at":      now,
+		},
+		"$push": bson.M{"reviews": review},
+	}
+	filter := notDeleted(bson.M{"_id": objID, "approval_status": model.KegiatanSubmitted})
+	res, err := config.DB.Collection("kegiatan").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to review kegiatan"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "Kegiatan status has changed, please reload"})
+	}
+	kegiatan.ApprovalStatus = input.Status
+	kegiatan.Reviews = append(kegiatan.Reviews, review)
+	kegiatan.UpdatedBy = review.ReviewerID
+	kegiatan.UpdatedAt = now
+
+	judul := "Kegiatan disetujui"
+	pesan := fmt.Sprintf("Pengajuan kegiatan \"%s\" telah disetujui", kegiatan.Judul)
+	if input.Status == model.KegiatanRejected {
+		judul = "Kegiatan ditolak"
+		pesan = fmt.Sprintf("Pengajuan kegiatan \"%s\" ditolak: %s", kegiatan.Judul, input.Komentar)
+	}
+	if creatorID, err := primitive.ObjectIDFromHex(kegiatan.CreatedBy); err == nil {
+		notify(ctx, []primitive.ObjectID{creatorID}, "kegiatan_"+input.Status, judul, pesan, objID)
+	}
+
+	return c.JSON(toKegiatanResponse(kegiatan))
+}
@@ -1,257 +1,633 @@
-package controller
-
-import (
-	"context"
-	"time"
-
-	"backend-sisteminformasi/config"
-	"backend-sisteminformasi/model"
-
-	"github.com/go-playground/validator/v10"
-	"github.com/gofiber/fiber/v2"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-)
-
-var kegiatanValidate = validator.New()
-
-// Tambahkan struct response
-type KegiatanResponse struct {
-	ID              string `json:"id"`
-	Judul           string `json:"judul"`
-	Deskripsi       string `json:"deskripsi"`
-	Tanggal         string `json:"tanggal"`
-	Lokasi          string `json:"lokasi"`
-	Kategori        string `json:"kategori"`
-	MaxParticipants int    `json:"maxParticipants"`
-	DokumentasiURL  string `json:"dokumentasi_url"`
-	CreatedBy       string `json:"created_by"`
-}
-
-func safeObjectIDHex(id interface{}) string {
-	if oid, ok := id.(primitive.ObjectID); ok {
-		return oid.Hex()
-	}
-	if s, ok := id.(string); ok {
-		return s
-	}
-	return ""
-}
-
-// GetKegiatan godoc
-// @Summary Get all kegiatan
-// @Tags Kegiatan
-// @Produce json
-// @Success 200 {array} model.Kegiatan
-// @Failure 500 {object} map[string]interface{}
-// @Router /kegiatan [get]
-// @Security BearerAuth
-func GetKegiatan(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	cursor, err := config.DB.Collection("kegiatan").Find(ctx, bson.M{})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
-	}
-	var rawKegiatans []bson.M
-	if err := cursor.All(ctx, &rawKegiatans); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode kegiatan"})
-	}
-	var responses []KegiatanResponse
-	for _, k := range rawKegiatans {
-		idStr := ""
-		if oid, ok := k["_id"].(primitive.ObjectID); ok {
-			idStr = oid.Hex()
-		} else if s, ok := k["_id"].(string); ok {
-			idStr = s
-		}
-		responses = append(responses, KegiatanResponse{
-			ID:              idStr,
-			Judul:           getStringFromMap(k, "judul"),
-			Deskripsi:       getStringFromMap(k, "deskripsi"),
-			Tanggal:         getStringFromMap(k, "tanggal"),
-			Lokasi:          getStringFromMap(k, "lokasi"),
-			Kategori:        getStringFromMap(k, "kategori"),
-			MaxParticipants: getIntFromMap(k, "maxParticipants"),
-			DokumentasiURL:  getStringFromMap(k, "dokumentasi_url"),
-			CreatedBy:       getStringFromMap(k, "created_by"),
-		})
-	}
-	return c.JSON(responses)
-}
-
-func getStringFromMap(m map[string]interface{}, key string) string {
-	if v, ok := m[key]; ok {
-		if s, ok := v.(string); ok {
-			return s
-		}
-	}
-	return ""
-}
-
-func getIntFromMap(m map[string]interface{}, key string) int {
-	if v, ok := m[key]; ok {
-		switch val := v.(type) {
-		case int:
-			return val
-		case int32:
-			return int(val)
-		case int64:
-			return int(val)
-		case float64:
-			return int(val)
-		}
-	}
-	return 0
-}
-
-// GetKegiatanByID godoc
-// @Summary Get kegiatan by ID
-// @Tags Kegiatan
-// @Produce json
-// @Param id path string true "Kegiatan ID"
-// @Success 200 {object} model.Kegiatan
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /kegiatan/{id} [get]
-// @Security BearerAuth
-func GetKegiatanByID(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	var kegiatan model.Kegiatan
-	err = config.DB.Collection("kegiatan").FindOne(ctx, bson.M{"_id": objID}).Decode(&kegiatan)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
-		}
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
-	}
-	response := KegiatanResponse{
-		ID:              safeObjectIDHex(kegiatan.ID),
-		Judul:           kegiatan.Judul,
-		Deskripsi:       kegiatan.Deskripsi,
-		Tanggal:         kegiatan.Tanggal,
-		Lokasi:          kegiatan.Lokasi,
-		Kategori:        kegiatan.Kategori,
-		MaxParticipants: kegiatan.MaxParticipants,
-		DokumentasiURL:  kegiatan.DokumentasiURL,
-		CreatedBy:       kegiatan.CreatedBy,
-	}
-	return c.JSON(response)
-}
-
-// CreateKegiatan godoc
-// @Summary Create kegiatan
-// @Tags Kegiatan
-// @Accept json
-// @Produce json
-// @Param kegiatan body model.Kegiatan true "Kegiatan Data"
-// @Success 201 {object} model.Kegiatan
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /kegiatan [post]
-// @Security BearerAuth
-func CreateKegiatan(c *fiber.Ctx) error {
-	var kegiatan model.Kegiatan
-	if err := c.BodyParser(&kegiatan); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
-	}
-	if err := kegiatanValidate.Struct(kegiatan); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	res, err := config.DB.Collection("kegiatan").InsertOne(ctx, kegiatan)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to create kegiatan"})
-	}
-	kegiatan.ID = res.InsertedID.(primitive.ObjectID)
-	response := KegiatanResponse{
-		ID:              kegiatan.ID.Hex(),
-		Judul:           kegiatan.Judul,
-		Deskripsi:       kegiatan.Deskripsi,
-		Tanggal:         kegiatan.Tanggal,
-		Lokasi:          kegiatan.Lokasi,
-		Kategori:        kegiatan.Kategori,
-		MaxParticipants: kegiatan.MaxParticipants,
-		DokumentasiURL:  kegiatan.DokumentasiURL,
-		CreatedBy:       kegiatan.CreatedBy,
-	}
-	return c.Status(201).JSON(response)
-}
-
-// UpdateKegiatan godoc
-// @Summary Update kegiatan
-// @Tags Kegiatan
-// @Accept json
-// @Produce json
-// @Param id path string true "Kegiatan ID"
-// @Param kegiatan body model.Kegiatan true "Kegiatan Data"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /kegiatan/{id} [put]
-// @Security BearerAuth
-func UpdateKegiatan(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-	var kegiatan model.Kegiatan
-	if err := c.BodyParser(&kegiatan); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
-	}
-	if err := kegiatanValidate.Struct(kegiatan); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	update := bson.M{
-		"judul":           kegiatan.Judul,
-		"deskripsi":       kegiatan.Deskripsi,
-		"tanggal":         kegiatan.Tanggal,
-		"lokasi":          kegiatan.Lokasi,
-		"kategori":        kegiatan.Kategori,
-		"maxParticipants": kegiatan.MaxParticipants,
-		"dokumentasi_url": kegiatan.DokumentasiURL,
-		"created_by":      kegiatan.CreatedBy,
-	}
-	_, err = config.DB.Collection("kegiatan").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": update})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to update kegiatan"})
-	}
-	return c.JSON(fiber.Map{"message": "Kegiatan updated"})
-}
-
-// DeleteKegiatan godoc
-// @Summary Delete kegiatan
-// @Tags Kegiatan
-// @Produce json
-// @Param id path string true "Kegiatan ID"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /kegiatan/{id} [delete]
-// @Security BearerAuth
-func DeleteKegiatan(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	_, err = config.DB.Collection("kegiatan").DeleteOne(ctx, bson.M{"_id": objID})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete kegiatan"})
-	}
-	return c.JSON(fiber.Map{"message": "Kegiatan deleted"})
-}
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var kegiatanValidate = validator.New()
+
+// Tambahkan struct response
+type KegiatanResponse struct {
+	ID              string                 `json:"id"`
+	Judul           string                 `json:"judul"`
+	Deskripsi       string                 `json:"deskripsi"`
+	Tanggal         string                 `json:"tanggal"`
+	TanggalSelesai  string                 `json:"tanggal_selesai,omitempty"`
+	JendelaCheckin  *model.JendelaCheckin  `json:"jendela_checkin,omitempty"`
+	Lokasi          string                 `json:"lokasi"`
+	Latitude        *float64               `json:"latitude,omitempty"`
+	Longitude       *float64               `json:"longitude,omitempty"`
+	RadiusMeter     int                    `json:"radius_meter,omitempty"`
+	Kategori        string                 `json:"kategori"`
+	CoHosts         []string               `json:"co_hosts,omitempty"`
+	MaxParticipants int                    `json:"maxParticipants"`
+	DokumentasiURL  string                 `json:"dokumentasi_url"`
+	Publik          bool                   `json:"publik"`
+	ApprovalStatus  string                 `json:"approval_status"`
+	Reviews         []model.KegiatanReview `json:"reviews,omitempty"`
+	CreatedBy       string                 `json:"created_by"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedBy       string                 `json:"updated_by"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+func toKegiatanResponse(kegiatan model.Kegiatan) KegiatanResponse {
+	return KegiatanResponse{
+		ID:              safeObjectIDHex(kegiatan.ID),
+		Judul:           kegiatan.Judul,
+		Deskripsi:       kegiatan.Deskripsi,
+		Tanggal:         kegiatan.Tanggal,
+		TanggalSelesai:  kegiatan.TanggalSelesai,
+		JendelaCheckin:  kegiatan.JendelaCheckin,
+		Lokasi:          kegiatan.Lokasi,
+		Latitude:        kegiatan.Latitude,
+		Longitude:       kegiatan.Longitude,
+		RadiusMeter:     kegiatan.RadiusMeter,
+		Kategori:        kegiatan.Kategori,
+		CoHosts:         kegiatan.CoHosts,
+		MaxParticipants: kegiatan.MaxParticipants,
+		DokumentasiURL:  kegiatan.DokumentasiURL,
+		Publik:          kegiatan.Publik,
+		ApprovalStatus:  approvalStatusOf(kegiatan),
+		Reviews:         kegiatan.Reviews,
+		CreatedBy:       kegiatan.CreatedBy,
+		CreatedAt:       kegiatan.CreatedAt,
+		UpdatedBy:       kegiatan.UpdatedBy,
+		UpdatedAt:       kegiatan.UpdatedAt,
+	}
+}
+
+var errInvalidID = errors.New("Invalid ID")
+
+// findKegiatanByID mengambil kegiatan yang belum dihapus berdasarkan ID hex
+func findKegiatanByID(ctx context.Context, id string) (model.Kegiatan, error) {
+	var kegiatan model.Kegiatan
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return kegiatan, errInvalidID
+	}
+	err = config.DB.Collection("kegiatan").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&kegiatan)
+	return kegiatan, err
+}
+
+// kegiatanErrorResponse menerjemahkan error dari findKegiatanByID menjadi response HTTP
+func kegiatanErrorResponse(c *fiber.Ctx, err error) error {
+	switch err {
+	case errInvalidID:
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	case mongo.ErrNoDocuments:
+		return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+	}
+	return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+}
+
+// validateHosts merapikan daftar co-host dan memastikan setiap UKM terdaftar.
+// Pesan yang dikembalikan ditujukan untuk response 400.
+func validateHosts(ctx context.Context, kegiatan *model.Kegiatan) (string, error) {
+	if len(kegiatan.CoHosts) == 0 {
+		kegiatan.CoHosts = nil
+		return "", nil
+	}
+	if kegiatan.Kategori == "" {
+		return "Kategori is required when co_hosts is set", nil
+	}
+	coHosts := []string{}
+	seen := map[string]bool{}
+	for _, ukm := range kegiatan.CoHosts {
+		if ukm == kegiatan.Kategori {
+			return "Co-host cannot be the same as the primary UKM", nil
+		}
+		if !seen[ukm] {
+			seen[ukm] = true
+			coHosts = append(coHosts, ukm)
+		}
+	}
+	count, err := config.DB.Collection("kategori").CountDocuments(ctx, notDeleted(bson.M{"nama_kategori": bson.M{"$in": coHosts}}))
+	if err != nil {
+		return "", err
+	}
+	if count != int64(len(coHosts)) {
+		return "Co-host UKM not found", nil
+	}
+	kegiatan.CoHosts = coHosts
+	return "", nil
+}
+
+// validateJadwal memastikan tanggal selesai (jika diisi) terbaca dan tidak lebih awal dari tanggal mulai
+func validateJadwal(kegiatan model.Kegiatan) string {
+	if kegiatan.TanggalSelesai == "" {
+		return ""
+	}
+	mulai, err := utils.ParseTanggal(kegiatan.Tanggal)
+	if err != nil {
+		return "Invalid tanggal format"
+	}
+	selesai, err := utils.ParseTanggal(kegiatan.TanggalSelesai)
+	if err != nil {
+		return "Invalid tanggal_selesai format"
+	}
+	if selesai.Before(mulai) {
+		return "tanggal_selesai must not be earlier than tanggal"
+	}
+	return ""
+}
+
+// hostsChanged mengecek apakah UKM tuan rumah atau daftar co-host berubah
+func hostsChanged(lama, baru model.Kegiatan) bool {
+	if lama.Kategori != baru.Kategori || len(lama.CoHosts) != len(baru.CoHosts) {
+		return true
+	}
+	seen := map[string]bool{}
+	for _, ukm := range lama.CoHosts {
+		seen[ukm] = true
+	}
+	for _, ukm := range baru.CoHosts {
+		if !seen[ukm] {
+			return true
+		}
+	}
+	return false
+}
+
+// checkKegiatanEditor memastikan admin boleh mengubah kegiatan. Co-host dapat mengubah
+// detail kegiatan, tetapi hanya admin UKM tuan rumah yang boleh mengganti penyelenggara.
+func checkKegiatanEditor(ctx context.Context, c *fiber.Ctx, lama, baru model.Kegiatan) (string, error) {
+	allowed, err := canManageKegiatan(ctx, c, lama)
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return "Only admins of the hosting UKMs can edit this kegiatan", nil
+	}
+	if hostsChanged(lama, baru) {
+		allowed, err = canManageUKM(ctx, c, lama.Kategori)
+		if err != nil {
+			return "", err
+		}
+		if !allowed {
+			return "Only admins of the primary UKM can change the hosts", nil
+		}
+	}
+	return "", nil
+}
+
+func safeObjectIDHex(id interface{}) string {
+	if oid, ok := id.(primitive.ObjectID); ok {
+		return oid.Hex()
+	}
+	if s, ok := id.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// GetKegiatan godoc
+// @Summary Get all kegiatan
+// @Description Member hanya melihat kegiatan yang sudah approved. Admin dan reviewer dapat memfilter dengan approval_status.
+// @Tags Kegiatan
+// @Produce json
+// @Param approval_status query string false "Filter status pengajuan (draft, submitted, approved, rejected)"
+// @Success 200 {array} model.Kegiatan
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan [get]
+// @Security BearerAuth
+func GetKegiatan(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filter := notDeleted(bson.M{})
+	if utils.GetUserRole(c) == "member" {
+		filter = approvedOnly(filter)
+	} else if status := c.Query("approval_status"); status == model.KegiatanApproved {
+		filter = approvedOnly(filter)
+	} else if status != "" {
+		filter["approval_status"] = status
+	}
+	cursor, err := config.DB.Collection("kegiatan").Find(ctx, filter)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	var rawKegiatans []bson.M
+	if err := cursor.All(ctx, &rawKegiatans); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode kegiatan"})
+	}
+	var responses []KegiatanResponse
+	for _, k := range rawKegiatans {
+		idStr := ""
+		if oid, ok := k["_id"].(primitive.ObjectID); ok {
+			idStr = oid.Hex()
+		} else if s, ok := k["_id"].(string); ok {
+			idStr = s
+		}
+		responses = append(responses, KegiatanResponse{
+			ID:              idStr,
+			Judul:           getStringFromMap(k, "judul"),
+			Deskripsi:       getStringFromMap(k, "deskripsi"),
+			Tanggal:         getStringFromMap(k, "tanggal"),
+			TanggalSelesai:  getStringFromMap(k, "tanggal_selesai"),
+			JendelaCheckin:  getJendelaFromMap(k, "jendela_checkin"),
+			Lokasi:          getStringFromMap(k, "lokasi"),
+			Latitude:        getFloatPtrFromMap(k, "latitude"),
+			Longitude:       getFloatPtrFromMap(k, "longitude"),
+			RadiusMeter:     getIntFromMap(k, "radius_meter"),
+			Kategori:        getStringFromMap(k, "kategori"),
+			CoHosts:         getStringsFromMap(k, "co_hosts"),
+			MaxParticipants: getIntFromMap(k, "maxParticipants"),
+			DokumentasiURL:  getStringFromMap(k, "dokumentasi_url"),
+			Publik:          k["publik"] == true,
+			ApprovalStatus:  approvalStatusOf(model.Kegiatan{ApprovalStatus: getStringFromMap(k, "approval_status")}),
+			CreatedBy:       getStringFromMap(k, "created_by"),
+			CreatedAt:       getTimeFromMap(k, "created_at"),
+			UpdatedBy:       getStringFromMap(k, "updated_by"),
+			UpdatedAt:       getTimeFromMap(k, "updated_at"),
+		})
+	}
+	return c.JSON(responses)
+}
+
+func getStringFromMap(m map[string]interface{}, key string) string {
+	if v, ok := m[key]; ok {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func getStringsFromMap(m map[string]interface{}, key string) []string {
+	values, ok := m[key].(bson.A)
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func getFloatPtrFromMap(m map[string]interface{}, key string) *float64 {
+	switch val := m[key].(type) {
+	case float64:
+		return &val
+	case int32:
+		f := float64(val)
+		return &f
+	case int64:
+		f := float64(val)
+		return &f
+	}
+	return nil
+}
+
+func getJendelaFromMap(m map[string]interface{}, key string) *model.JendelaCheckin {
+	v, ok := m[key].(bson.M)
+	if !ok {
+		return nil
+	}
+	return &model.JendelaCheckin{
+		BukaSebelumMenit:        getIntFromMap(v, "buka_sebelum_menit"),
+		TutupSetelahMenit:       getIntFromMap(v, "tutup_setelah_menit"),
+		ToleransiTerlambatMenit: getIntFromMap(v, "toleransi_terlambat_menit"),
+	}
+}
+
+func getTimeFromMap(m map[string]interface{}, key string) time.Time {
+	if v, ok := m[key]; ok {
+		switch val := v.(type) {
+		case primitive.DateTime:
+			return val.Time()
+		case time.Time:
+			return val
+		}
+	}
+	return time.Time{}
+}
+
+func getIntFromMap(m map[string]interface{}, key string) int {
+	if v, ok := m[key]; ok {
+		switch val := v.(type) {
+		case int:
+			return val
+		case int32:
+			return int(val)
+		case int64:
+			return int(val)
+		case float64:
+			return int(val)
+		}
+	}
+	return 0
+}
+
+// GetKegiatanByID godoc
+// @Summary Get kegiatan by ID
+// @Tags Kegiatan
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} model.Kegiatan
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id} [get]
+// @Security BearerAuth
+func GetKegiatanByID(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filter := notDeleted(bson.M{"_id": objID})
+	if utils.GetUserRole(c) == "member" {
+		filter = approvedOnly(filter)
+	}
+	var kegiatan model.Kegiatan
+	err = config.DB.Collection("kegiatan").FindOne(ctx, filter).Decode(&kegiatan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	return c.JSON(toKegiatanResponse(kegiatan))
+}
+
+// CreateKegiatan godoc
+// @Summary Create kegiatan
+// @Description Kegiatan baru disimpan sebagai draft dan perlu di-submit untuk direview
+// @Tags Kegiatan
+// @Accept json
+// @Produce json
+// @Param kegiatan body model.Kegiatan true "Kegiatan Data"
+// @Success 201 {object} model.Kegiatan
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan [post]
+// @Security BearerAuth
+func CreateKegiatan(c *fiber.Ctx) error {
+	var kegiatan model.Kegiatan
+	if err := c.BodyParser(&kegiatan); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := kegiatanValidate.Struct(kegiatan); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if msg := validateJadwal(kegiatan); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msg, err := validateHosts(ctx, &kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate co-hosts"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	// Kegiatan baru selalu berupa draft sampai disetujui reviewer
+	kegiatan.ApprovalStatus = model.KegiatanDraft
+	kegiatan.Reviews = nil
+	// Data audit diambil dari token, bukan dari body request
+	now := time.Now()
+	kegiatan.CreatedBy = utils.GetUserID(c)
+	kegiatan.CreatedAt = now
+	kegiatan.UpdatedBy = kegiatan.CreatedBy
+	kegiatan.UpdatedAt = now
+	kegiatan.DeletedAt, kegiatan.DeletedBy = nil, ""
+	res, err := config.DB.Collection("kegiatan").InsertOne(ctx, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create kegiatan"})
+	}
+	kegiatan.ID = res.InsertedID.(primitive.ObjectID)
+	return c.Status(201).JSON(toKegiatanResponse(kegiatan))
+}
+
+// UpdateKegiatan godoc
+// @Summary Update kegiatan
+// @Description Admin UKM co-host dapat mengubah detail kegiatan, tetapi kategori dan co_hosts hanya dapat diubah admin UKM tuan rumah
+// @Description Perubahan isi (judul, deskripsi, jadwal, lokasi, kuota, publik, penyelenggara) ditolak selama kegiatan direview dan mengembalikan kegiatan yang sudah disetujui ke draft
+// @Tags Kegiatan
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param kegiatan body model.Kegiatan true "Kegiatan Data"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /kegiatan/{id} [put]
+// @Security BearerAuth
+func UpdateKegiatan(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	var kegiatan model.Kegiatan
+	if err := c.BodyParser(&kegiatan); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := kegiatanValidate.Struct(kegiatan); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if msg := validateJadwal(kegiatan); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	lama, err := findKegiatanByID(ctx, id)
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	msg, err := validateHosts(ctx, &kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate co-hosts"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	msg, err = checkKegiatanEditor(ctx, c, lama, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if msg != "" {
+		return c.Status(403).JSON(fiber.Map{"error": msg})
+	}
+	update := bson.M{
+		"judul":           kegiatan.Judul,
+		"deskripsi":       kegiatan.Deskripsi,
+		"tanggal":         kegiatan.Tanggal,
+		"tanggal_selesai": kegiatan.TanggalSelesai,
+		"jendela_checkin": kegiatan.JendelaCheckin,
+		"lokasi":          kegiatan.Lokasi,
+		"latitude":        kegiatan.Latitude,
+		"longitude":       kegiatan.Longitude,
+		"radius_meter":    kegiatan.RadiusMeter,
+		"kategori":        kegiatan.Kategori,
+		"co_hosts":        kegiatan.CoHosts,
+		"maxParticipants": kegiatan.MaxParticipants,
+		"dokumentasi_url": kegiatan.DokumentasiURL,
+		"publik":          kegiatan.Publik,
+		"updated_by":      utils.GetUserID(c),
+		"updated_at":      time.Now(),
+	}
+	if msg := terapkanStatusEdit(lama, kegiatan, update); msg != "" {
+		return c.Status(409).JSON(fiber.Map{"error": msg})
+	}
+	res, err := config.DB.Collection("kegiatan").UpdateOne(ctx, filterStatusLama(objID, lama), bson.M{"$set": update})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update kegiatan"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "Kegiatan status has changed, please reload"})
+	}
+	if update["approval_status"] == model.KegiatanDraft {
+		return c.JSON(fiber.Map{"message": "Kegiatan updated and returned to draft, please resubmit for approval"})
+	}
+	return c.JSON(fiber.Map{"message": "Kegiatan updated"})
+}
+
+// PatchKegiatan godoc
+// @Summary Partially update kegiatan
+// @Description Hanya field yang dikirim yang diubah (JSON Merge Patch), field bernilai null dikosongkan. Aturan hak akses co-host dan status pengajuan sama dengan PUT.
+// @Tags Kegiatan
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param kegiatan body model.Kegiatan true "Field kegiatan yang diubah"
+// @Success 200 {object} KegiatanResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /kegiatan/{id} [patch]
+// @Security BearerAuth
+func PatchKegiatan(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var kegiatan model.Kegiatan
+	err = config.DB.Collection("kegiatan").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&kegiatan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	lama := kegiatan
+	update, err := applyMergePatch(c.Body(), &kegiatan, "approval_status", "reviews")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := kegiatanValidate.Struct(kegiatan); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if msg := validateJadwal(kegiatan); msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	msg, err := validateHosts(ctx, &kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate co-hosts"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	msg, err = checkKegiatanEditor(ctx, c, lama, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if msg != "" {
+		return c.Status(403).JSON(fiber.Map{"error": msg})
+	}
+	if _, ok := update["co_hosts"]; ok {
+		update["co_hosts"] = kegiatan.CoHosts
+	}
+	if msg := terapkanStatusEdit(lama, kegiatan, update); msg != "" {
+		return c.Status(409).JSON(fiber.Map{"error": msg})
+	}
+	if status, ok := update["approval_status"].(string); ok {
+		kegiatan.ApprovalStatus = status
+	}
+	kegiatan.ID = objID
+	kegiatan.UpdatedBy = utils.GetUserID(c)
+	kegiatan.UpdatedAt = time.Now()
+	update["updated_by"] = kegiatan.UpdatedBy
+	update["updated_at"] = kegiatan.UpdatedAt
+	res, err := config.DB.Collection("kegiatan").UpdateOne(ctx, filterStatusLama(objID, lama), bson.M{"$set": update})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update kegiatan"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "Kegiatan status has changed, please reload"})
+	}
+	return c.JSON(toKegiatanResponse(kegiatan))
+}
+
+// DeleteKegiatan godoc
+// @Summary Delete kegiatan (soft delete)
+// @Description Hanya admin UKM tuan rumah yang dapat menghapus, co-host tidak
+// @Tags Kegiatan
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /kegiatan/{id} [delete]
+// @Security BearerAuth
+func DeleteKegiatan(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, id)
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageUKM(ctx, c, kegiatan.Kategori)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the primary UKM can delete this kegiatan"})
+	}
+	// Soft delete: data dipindah ke tempat sampah dan masih bisa di-restore
+	update := bson.M{"$set": bson.M{
+		"deleted_at": time.Now(),
+		"deleted_by": utils.GetUserID(c),
+	}}
+	res, err := config.DB.Collection("kegiatan").UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete kegiatan"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+	}
+	return c.JSON(fiber.Map{"message": "Kegiatan deleted"})
+}
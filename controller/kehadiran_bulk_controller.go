@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Hasil pencatatan per user pada kehadiran massal
+const (
+	BulkDibuat       = "created"
+	BulkDiperbarui   = "updated"
+	BulkTidakBerubah = "unchanged"
+	BulkAlpaOtomatis = "auto_alpa"
+	BulkGagal        = "failed"
+)
+
+type BulkKehadiranItem struct {
+	UserID string `json:"user_id" validate:"required"`
+	Status string `json:"status" validate:"required,oneof=hadir terlambat izin sakit alpa tidak"`
+}
+
+type BulkKehadiranRequest struct {
+	Kehadiran []BulkKehadiranItem `json:"kehadiran" validate:"required,min=1,max=1000,dive"`
+}
+
+type BulkKehadiranHasil struct {
+	UserID string `json:"user_id"`
+	Status string `json:"status,omitempty"`
+	Hasil  string `json:"hasil"`
+	Pesan  string `json:"pesan,omitempty"`
+}
+
+type BulkKehadiranResponse struct {
+	Dibuat       int                  `json:"dibuat"`
+	Diperbarui   int                  `json:"diperbarui"`
+	TidakBerubah int                  `json:"tidak_berubah"`
+	AlpaOtomatis int                  `json:"alpa_otomatis"`
+	Gagal        int                  `json:"gagal"`
+	Hasil        []BulkKehadiranHasil `json:"hasil"`
+}
+
+// kehadiranPerUser mengambil kehadiran aktif sebuah kegiatan, dikelompokkan per user
+func kehadiranPerUser(ctx context.Context, kegiatanID primitive.ObjectID) (map[string]model.Kehadiran, error) {
+	cursor, err := config.DB.Collection("kehadiran").Find(ctx, notDeleted(bson.M{"kegiatan_id": kegiatanID}))
+	if err != nil {
+		return nil, err
+	}
+	var kehadirans []model.Kehadiran
+	if err := cursor.All(ctx, &kehadirans); err != nil {
+		return nil, err
+	}
+	result := make(map[string]model.Kehadiran, len(kehadirans))
+	for _, k := range kehadirans {
+		result[k.UserID.Hex()] = k
+	}
+	return result, nil
+}
+
+// userTerdaftar mengecek user mana saja dari daftar ID yang ada di database
+func userTerdaftar(ctx context.Context, ids []string) (map[string]bool, error) {
+	objIDs := []primitive.ObjectID{}
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	found, err := findUserIDs(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(found))
+	for _, id := range found {
+		result[id.Hex()] = true
+	}
+	return result, nil
+}
+
+// BulkKehadiran godoc
+// @Summary Mark kehadiran of many members at once
+// @Description Mencatat atau memperbarui kehadiran banyak user sekaligus dalam satu operasi. Jika jendela check-in sudah
+// @Description ditutup, peserta terdaftar yang tidak ada di daftar dan belum memiliki kehadiran otomatis dicatat alpa.
+// @Tags Kehadiran
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param body body BulkKehadiranRequest true "Daftar user dan status kehadiran"
+// @Success 200 {object} BulkKehadiranResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/kehadiran/bulk [post]
+// @Security BearerAuth
+func BulkKehadiran(c *fiber.Ctx) error {
+	var input BulkKehadiranRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := kehadiranValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only organizers can mark kehadiran for this kegiatan"})
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(409).JSON(fiber.Map{"error": "Kegiatan is not approved yet"})
+	}
+
+	userIDs := make([]string, 0, len(input.Kehadiran))
+	for _, item := range input.Kehadiran {
+		userIDs = append(userIDs, item.UserID)
+	}
+	userAda, err := userTerdaftar(ctx, userIDs)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch users"})
+	}
+	existing, err := kehadiranPerUser(ctx, kegiatan.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+
+	adminID := utils.GetUserID(c)
+	now := time.Now()
+	waktuCek := now.In(utils.Location).Format(time.RFC3339)
+	// userID sudah dipastikan valid oleh userTerdaftar atau berasal dari pesertaKegiatan
+	baru := func(userID, status string) model.Kehadiran {
+		objID, _ := primitive.ObjectIDFromHex(userID)
+		k := model.Kehadiran{
+			UserID:     objID,
+			KegiatanID: kegiatan.ID,
+			Status:     status,
+			Metode:     model.MetodeAdmin,
+			CreatedBy:  adminID,
+			CreatedAt:  now,
+			UpdatedBy:  adminID,
+			UpdatedAt:  now,
+		}
+		if isCheckin(status) {
+			k.WaktuCek = waktuCek
+		}
+		return k
+	}
+
+	response := BulkKehadiranResponse{Hasil: []BulkKehadiranHasil{}}
+	models := []mongo.WriteModel{}
+	// Indeks hasil untuk setiap operasi, dipakai memetakan error bulk write ke user
+	hasilModel := []int{}
+	tambah := func(hasil BulkKehadiranHasil, op mongo.WriteModel) {
+		response.Hasil = append(response.Hasil, hasil)
+		if op != nil {
+			models = append(models, op)
+			hasilModel = append(hasilModel, len(response.Hasil)-1)
+		}
+	}
+
+	dicatat := map[string]bool{}
+	for _, item := range input.Kehadiran {
+		hasil := BulkKehadiranHasil{UserID: item.UserID, Status: item.Status}
+		switch {
+		case dicatat[item.UserID]:
+			hasil.Hasil, hasil.Pesan = BulkGagal, "duplicate user in request"
+			tambah(hasil, nil)
+			continue
+		case !userAda[item.UserID]:
+			hasil.Hasil, hasil.Pesan = BulkGagal, "user not found"
+			tambah(hasil, nil)
+			continue
+		}
+		dicatat[item.UserID] = true
+
+		lama, found := existing[item.UserID]
+		if !found {
+			hasil.Hasil = BulkDibuat
+			tambah(hasil, mongo.NewInsertOneModel().SetDocument(baru(item.UserID, item.Status)))
+			continue
+		}
+		if lama.Status == item.Status && lama.Verifikasi == "" {
+			hasil.Hasil = BulkTidakBerubah
+			tambah(hasil, nil)
+			continue
+		}
+		objID, _ := primitive.ObjectIDFromHex(lama.ID)
+		set := bson.M{"status": item.Status, "metode": model.MetodeAdmin, "updated_by": adminID, "updated_at": now}
+		if isCheckin(item.Status) && lama.WaktuCek == "" {
+			set["waktu_cek"] = waktuCek
+		}
+		// Keputusan panitia menggantikan status verifikasi sebelumnya
+		update := bson.M{"$set": set, "$unset": bson.M{"verifikasi": "", "catatan_verifikasi": "", "verified_by": "", "verified_at": ""}}
+		hasil.Hasil = BulkDiperbarui
+		tambah(hasil, mongo.NewUpdateOneModel().SetFilter(notDeleted(bson.M{"_id": objID})).SetUpdate(update))
+	}
+
+	if checkinDitutup(kegiatan, now) {
+		peserta, err := pesertaKegiatan(ctx, kegiatan)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch participants"})
+		}
+		for _, id := range peserta {
+			userID := id.Hex()
+			if _, found := existing[userID]; found || dicatat[userID] {
+				continue
+			}
+			hasil := BulkKehadiranHasil{UserID: userID, Status: model.StatusAlpa, Hasil: BulkAlpaOtomatis}
+			tambah(hasil, mongo.NewInsertOneModel().SetDocument(baru(userID, model.StatusAlpa)))
+		}
+	}
+
+	if len(models) > 0 {
+		_, err := config.DB.Collection("kehadiran").BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		var bulkErr mongo.BulkWriteException
+		if errors.As(err, &bulkErr) {
+			for _, we := range bulkErr.WriteErrors {
+				hasil := &response.Hasil[hasilModel[we.Index]]
+				hasil.Hasil, hasil.Pesan = BulkGagal, "failed to save: "+we.Message
+				if mongo.IsDuplicateKeyError(we) {
+					hasil.Pesan = "kehadiran was recorded by another request, please retry"
+				}
+			}
+		} else if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to save kehadiran"})
+		}
+	}
+
+	for _, hasil := range response.Hasil {
+		switch hasil.Hasil {
+		case BulkDibuat:
+			response.Dibuat++
+		case BulkDiperbarui:
+			response.Diperbarui++
+		case BulkTidakBerubah:
+			response.TidakBerubah++
+		case BulkAlpaOtomatis:
+			response.AlpaOtomatis++
+		case BulkGagal:
+			response.Gagal++
+		}
+	}
+	return c.JSON(response)
+}
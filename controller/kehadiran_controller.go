@@ -1,216 +1,481 @@
-package controller
-
-import (
-	"context"
-	"time"
-
-	"backend-sisteminformasi/config"
-	"backend-sisteminformasi/model"
-
-	"github.com/go-playground/validator/v10"
-	"github.com/gofiber/fiber/v2"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-)
-
-var kehadiranValidate = validator.New()
-
-// @Security BearerAuth
-// GetKehadiran godoc
-// @Summary Get all kehadiran
-// @Tags Kehadiran
-// @Produce json
-// @Success 200 {array} model.Kehadiran
-// @Failure 500 {object} map[string]interface{}
-// @Router /kehadiran [get]
-func GetKehadiran(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Aggregation pipeline untuk JOIN dengan users dan kegiatan
-	pipeline := []bson.M{
-		{
-			"$addFields": bson.M{
-				"user_object_id": bson.M{
-					"$cond": bson.M{
-						"if":   bson.M{"$eq": []interface{}{bson.M{"$type": "$user_id"}, "string"}},
-						"then": bson.M{"$toObjectId": "$user_id"},
-						"else": "$user_id",
-					},
-				},
-				"kegiatan_object_id": bson.M{
-					"$cond": bson.M{
-						"if":   bson.M{"$eq": []interface{}{bson.M{"$type": "$kegiatan_id"}, "string"}},
-						"then": bson.M{"$toObjectId": "$kegiatan_id"},
-						"else": "$kegiatan_id",
-					},
-				},
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"from":         "users",
-				"localField":   "user_object_id",
-				"foreignField": "_id",
-				"as":           "user_data",
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"from":         "kegiatan",
-				"localField":   "kegiatan_object_id",
-				"foreignField": "_id",
-				"as":           "kegiatan_data",
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id":           1,
-				"user_id":       1,
-				"kegiatan_id":   1,
-				"status":        1,
-				"waktu_cek":     1,
-				"user_nama":     bson.M{"$arrayElemAt": []interface{}{"$user_data.nama", 0}},
-				"kegiatan_nama": bson.M{"$arrayElemAt": []interface{}{"$kegiatan_data.judul", 0}},
-				"ukm":           bson.M{"$arrayElemAt": []interface{}{"$kegiatan_data.kategori", 0}},
-			},
-		},
-	}
-
-	cursor, err := config.DB.Collection("kehadiran").Aggregate(ctx, pipeline)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
-	}
-
-	var kehadirans []bson.M
-	if err := cursor.All(ctx, &kehadirans); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode kehadiran"})
-	}
-
-	return c.JSON(kehadirans)
-}
-
-// @Security BearerAuth
-// GetKehadiranByID godoc
-// @Summary Get kehadiran by ID
-// @Tags Kehadiran
-// @Produce json
-// @Param id path string true "Kehadiran ID"
-// @Success 200 {object} model.Kehadiran
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /kehadiran/{id} [get]
-func GetKehadiranByID(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	var kehadiran model.Kehadiran
-	err = config.DB.Collection("kehadiran").FindOne(ctx, bson.M{"_id": objID}).Decode(&kehadiran)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return c.Status(404).JSON(fiber.Map{"error": "Kehadiran not found"})
-		}
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
-	}
-	return c.JSON(kehadiran)
-}
-
-// @Security BearerAuth
-// CreateKehadiran godoc
-// @Summary Create kehadiran
-// @Tags Kehadiran
-// @Accept json
-// @Produce json
-// @Param kehadiran body model.Kehadiran true "Kehadiran Data"
-// @Success 201 {object} model.Kehadiran
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /kehadiran [post]
-func CreateKehadiran(c *fiber.Ctx) error {
-	var kehadiran model.Kehadiran
-	if err := c.BodyParser(&kehadiran); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
-	}
-	if err := kehadiranValidate.Struct(kehadiran); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	res, err := config.DB.Collection("kehadiran").InsertOne(ctx, kehadiran)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to create kehadiran"})
-	}
-	kehadiran.ID = res.InsertedID.(primitive.ObjectID).Hex()
-	return c.Status(201).JSON(kehadiran)
-}
-
-// @Security BearerAuth
-// UpdateKehadiran godoc
-// @Summary Update kehadiran
-// @Tags Kehadiran
-// @Accept json
-// @Produce json
-// @Param id path string true "Kehadiran ID"
-// @Param kehadiran body model.Kehadiran true "Kehadiran Data"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /kehadiran/{id} [put]
-func UpdateKehadiran(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-	var kehadiran model.Kehadiran
-	if err := c.BodyParser(&kehadiran); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
-	}
-	if err := kehadiranValidate.Struct(kehadiran); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	update := bson.M{
-		"user_id":     kehadiran.UserID,
-		"kegiatan_id": kehadiran.KegiatanID,
-		"status":      kehadiran.Status,
-		"waktu_cek":   kehadiran.WaktuCek,
-	}
-	_, err = config.DB.Collection("kehadiran").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": update})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to update kehadiran"})
-	}
-	return c.JSON(fiber.Map{"message": "Kehadiran updated"})
-}
-
-// @Security BearerAuth
-// DeleteKehadiran godoc
-// @Summary Delete kehadiran
-// @Tags Kehadiran
-// @Produce json
-// @Param id path string true "Kehadiran ID"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /kehadiran/{id} [delete]
-func DeleteKehadiran(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	_, err = config.DB.Collection("kehadiran").DeleteOne(ctx, bson.M{"_id": objID})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete kehadiran"})
-	}
-	return c.JSON(fiber.Map{"message": "Kehadiran deleted"})
-}
+package controller
+
+import (
+	"context"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var kehadiranValidate = validator.New()
+
+// kehadiranPipeline membuat aggregation pipeline untuk JOIN kehadiran dengan users dan kegiatan.
+// Field sensitif seperti lokasi_cek hanya ikut jika disebut di fieldTambahan (khusus tampilan admin).
+func kehadiranPipeline(match bson.M, fieldTambahan ...string) []bson.M {
+	project := bson.M{
+		"_id":              1,
+		"user_id":          1,
+		"kegiatan_id":      1,
+		"status":           1,
+		"waktu_cek":        1,
+		"metode":           1,
+		"verifikasi":       1,
+		"user_nama":        bson.M{"$arrayElemAt": []interface{}{"$user_data.nama", 0}},
+		"kegiatan_nama":    bson.M{"$arrayElemAt": []interface{}{"$kegiatan_data.judul", 0}},
+		"kegiatan_tanggal": bson.M{"$arrayElemAt": []interface{}{"$kegiatan_data.tanggal", 0}},
+		"ukm":              bson.M{"$arrayElemAt": []interface{}{"$kegiatan_data.kategori", 0}},
+	}
+	for _, field := range fieldTambahan {
+		project[field] = 1
+	}
+	return []bson.M{
+		{
+			"$match": match,
+		},
+		{
+			"$lookup": bson.M{
+				"from":         "users",
+				"localField":   "user_id",
+				"foreignField": "_id",
+				"as":           "user_data",
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"from": "kegiatan",
+				"let":  bson.M{"kegiatan_id": "$kegiatan_id"},
+				"pipeline": []bson.M{
+					{
+						"$match": notDeleted(bson.M{
+							"$expr": bson.M{"$eq": []interface{}{"$_id", "$$kegiatan_id"}},
+						}),
+					},
+				},
+				"as": "kegiatan_data",
+			},
+		},
+		{
+			// Kehadiran milik kegiatan yang sudah dihapus tidak ditampilkan
+			"$match": bson.M{"kegiatan_data.0": bson.M{"$exists": true}},
+		},
+		{
+			"$project": project,
+		},
+	}
+}
+
+// filterHadir menambahkan syarat kehadiran yang dihitung hadir (termasuk terlambat).
+// Check-in di luar radius yang belum diverifikasi admin belum dihitung.
+func filterHadir(filter bson.M) bson.M {
+	filter["status"] = bson.M{"$in": []string{model.StatusHadir, model.StatusTerlambat}}
+	filter["verifikasi"] = bson.M{"$ne": model.VerifikasiPending}
+	return filter
+}
+
+// tingkatKehadiran menghitung persentase hadir (termasuk terlambat) dari peserta yang
+// wajib hadir. Izin dan sakit tidak ikut dihitung sebagai ketidakhadiran.
+func tingkatKehadiran(perStatus map[string]int64) float64 {
+	hadir := perStatus[model.StatusHadir] + perStatus[model.StatusTerlambat]
+	wajib := hadir + perStatus[model.StatusAlpa] + perStatus[model.StatusTidak]
+	if wajib == 0 {
+		return 0
+	}
+	return float64(hadir) / float64(wajib) * 100
+}
+
+// validateKehadiranRefs memastikan user dan kegiatan yang dirujuk kehadiran masih ada.
+// Pesan tidak kosong berarti referensi tidak valid.
+func validateKehadiranRefs(ctx context.Context, kehadiran model.Kehadiran) (string, error) {
+	count, err := config.DB.Collection("users").CountDocuments(ctx, notDeleted(bson.M{"_id": kehadiran.UserID}))
+	if err != nil {
+		return "", err
+	}
+	if count == 0 {
+		return "User not found", nil
+	}
+	count, err = config.DB.Collection("kegiatan").CountDocuments(ctx, notDeleted(bson.M{"_id": kehadiran.KegiatanID}))
+	if err != nil {
+		return "", err
+	}
+	if count == 0 {
+		return "Kegiatan not found", nil
+	}
+	return "", nil
+}
+
+// isCheckin mengecek apakah status menandakan peserta datang ke kegiatan
+func isCheckin(status string) bool {
+	return status == model.StatusHadir || status == model.StatusTerlambat
+}
+
+// simpanKehadiran menyimpan kehadiran baru. Jika user sudah punya kehadiran untuk kegiatan
+// yang sama, data lama dikembalikan (created=false) sehingga check-in berulang aman.
+// Check-in yang datang setelah status tidak hadir (izin/sakit/alpa) menggantikan status lama.
+func simpanKehadiran(ctx context.Context, kehadiran model.Kehadiran) (model.Kehadiran, bool, error) {
+	collection := config.DB.Collection("kehadiran")
+	filter := notDeleted(bson.M{"user_id": kehadiran.UserID, "kegiatan_id": kehadiran.KegiatanID})
+	var existing model.Kehadiran
+	err := collection.FindOne(ctx, filter).Decode(&existing)
+	if err == mongo.ErrNoDocuments {
+		res, err := collection.InsertOne(ctx, kehadiran)
+		if err == nil {
+			kehadiran.ID = res.InsertedID.(primitive.ObjectID).Hex()
+			return kehadiran, true, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return kehadiran, false, err
+		}
+		// Request bersamaan sudah lebih dulu menyimpan, ambil data tersebut
+		err = collection.FindOne(ctx, filter).Decode(&existing)
+	}
+	if err != nil {
+		return kehadiran, false, err
+	}
+	if !isCheckin(kehadiran.Status) || isCheckin(existing.Status) || kehadiran.Metode == model.MetodeAdmin {
+		return existing, false, nil
+	}
+
+	objID, _ := primitive.ObjectIDFromHex(existing.ID)
+	kehadiran.ID = existing.ID
+	kehadiran.CreatedBy, kehadiran.CreatedAt = existing.CreatedBy, existing.CreatedAt
+	update := bson.M{
+		"$set": bson.M{
+			"status":     kehadiran.Status,
+			"waktu_cek":  kehadiran.WaktuCek,
+			"metode":     kehadiran.Metode,
+			"lokasi_cek": kehadiran.LokasiCek,
+			"verifikasi": kehadiran.Verifikasi,
+			"updated_by": kehadiran.UpdatedBy,
+			"updated_at": kehadiran.UpdatedAt,
+		},
+		"$unset": bson.M{"alasan": "", "dokumen_url": "", "catatan_verifikasi": "", "verified_by": "", "verified_at": ""},
+	}
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": objID}, update); err != nil {
+		return kehadiran, false, err
+	}
+	return kehadiran, false, nil
+}
+
+// @Security BearerAuth
+// GetKehadiran godoc
+// @Summary Get all kehadiran
+// @Tags Kehadiran
+// @Produce json
+// @Success 200 {array} model.Kehadiran
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran [get]
+func GetKehadiran(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.DB.Collection("kehadiran").Aggregate(ctx, kehadiranPipeline(notDeleted(bson.M{})))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+
+	var kehadirans []bson.M
+	if err := cursor.All(ctx, &kehadirans); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode kehadiran"})
+	}
+
+	return c.JSON(kehadirans)
+}
+
+// @Security BearerAuth
+// GetKehadiranByID godoc
+// @Summary Get kehadiran by ID
+// @Tags Kehadiran
+// @Produce json
+// @Param id path string true "Kehadiran ID"
+// @Success 200 {object} model.Kehadiran
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/{id} [get]
+func GetKehadiranByID(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var kehadiran model.Kehadiran
+	err = config.DB.Collection("kehadiran").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&kehadiran)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Kehadiran not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+	// Alasan izin, dokumen pendukung dan lokasi check-in hanya untuk admin dan pemiliknya
+	if utils.GetUserRole(c) != "admin" && kehadiran.UserID.Hex() != utils.GetUserID(c) {
+		kehadiran.Alasan, kehadiran.DokumenURL, kehadiran.LokasiCek = "", "", nil
+	}
+	return c.JSON(kehadiran)
+}
+
+// @Security BearerAuth
+// CreateKehadiranRequest adalah body POST /kehadiran. Token hanya dipakai check-in mandiri.
+type CreateKehadiranRequest struct {
+	model.Kehadiran
+	Token string `json:"token"`
+}
+
+// CreateKehadiran godoc
+// @Summary Create kehadiran
+// @Description Non-admin hanya dapat mencatat kehadiran dirinya sendiri dengan token dari kode QR kegiatan: user_id diambil dari JWT,
+// @Description waktu_cek dan status diisi server seperti POST /kegiatan/{id}/checkin.
+// @Description Admin UKM penyelenggara mencatat kehadiran user lain dengan waktu_cek saat ini, hanya untuk kegiatan approved
+// @Description dan selama jendela check-in belum ditutup. Koreksi setelahnya memakai PATCH /kehadiran/{id}.
+// @Description Satu user hanya memiliki satu kehadiran per kegiatan: jika sudah ada, data lama dikembalikan dengan status 200.
+// @Tags Kehadiran
+// @Accept json
+// @Produce json
+// @Param kehadiran body CreateKehadiranRequest true "Kehadiran Data"
+// @Success 201 {object} model.Kehadiran
+// @Success 200 {object} model.Kehadiran
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran [post]
+func CreateKehadiran(c *fiber.Ctx) error {
+	var input CreateKehadiranRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if utils.GetUserRole(c) != "admin" {
+		// Check-in mandiri selalu atas nama user yang login dan wajib membawa kode QR yang masih berlaku
+		if input.KegiatanID.IsZero() {
+			return c.Status(400).JSON(fiber.Map{"error": "kegiatan_id is required"})
+		}
+		checkin := CheckinRequest{Token: input.Token, LokasiCek: input.LokasiCek}
+		if err := checkinValidate.Struct(checkin); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		return checkinMandiri(c, input.KegiatanID.Hex(), checkin)
+	}
+	kehadiran := input.Kehadiran
+	now := time.Now()
+	// Status verifikasi hanya diisi server, client_id khusus untuk sinkronisasi offline
+	kehadiran.Verifikasi, kehadiran.CatatanVerifikasi = "", ""
+	kehadiran.VerifiedBy, kehadiran.VerifiedAt = "", nil
+	kehadiran.ClientID = ""
+	kehadiran.Metode = model.MetodeAdmin
+	kehadiran.LokasiCek = nil
+	// Koreksi waktu kehadiran yang sudah lewat dilakukan lewat PATCH
+	kehadiran.WaktuCek = now.In(utils.Location).Format(time.RFC3339)
+	if err := kehadiranValidate.Struct(kehadiran); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msg, err := validateKehadiranRefs(ctx, kehadiran)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate kehadiran"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	kegiatan, err := findKegiatanByID(ctx, kehadiran.KegiatanID.Hex())
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can record kehadiran for this kegiatan"})
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(409).JSON(fiber.Map{"error": "Kegiatan is not approved yet"})
+	}
+	// Hadir/terlambat hanya di dalam jendela check-in, status lain ditolak setelah jendela ditutup
+	if isCheckin(kehadiran.Status) {
+		if _, msg := statusCheckin(kegiatan, now); msg != "" {
+			return c.Status(409).JSON(fiber.Map{"error": msg + ", use PATCH /kehadiran/{id} to correct past records"})
+		}
+	} else if checkinDitutup(kegiatan, now) {
+		return c.Status(409).JSON(fiber.Map{"error": "Check-in has closed, use PATCH /kehadiran/{id} to correct past records"})
+	}
+	kehadiran.ID = ""
+	kehadiran.CreatedBy = utils.GetUserID(c)
+	kehadiran.CreatedAt = now
+	kehadiran.UpdatedBy = kehadiran.CreatedBy
+	kehadiran.UpdatedAt = now
+	kehadiran.DeletedAt, kehadiran.DeletedBy = nil, ""
+	kehadiran, created, err := simpanKehadiran(ctx, kehadiran)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create kehadiran"})
+	}
+	if !created {
+		return c.JSON(kehadiran)
+	}
+	return c.Status(201).JSON(kehadiran)
+}
+
+// @Security BearerAuth
+// UpdateKehadiran godoc
+// @Summary Update kehadiran
+// @Tags Kehadiran
+// @Accept json
+// @Produce json
+// @Param id path string true "Kehadiran ID"
+// @Param kehadiran body model.Kehadiran true "Kehadiran Data"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/{id} [put]
+func UpdateKehadiran(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	var kehadiran model.Kehadiran
+	if err := c.BodyParser(&kehadiran); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := kehadiranValidate.Struct(kehadiran); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msg, err := validateKehadiranRefs(ctx, kehadiran)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate kehadiran"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	update := bson.M{
+		"user_id":     kehadiran.UserID,
+		"kegiatan_id": kehadiran.KegiatanID,
+		"status":      kehadiran.Status,
+		"waktu_cek":   kehadiran.WaktuCek,
+		"updated_by":  utils.GetUserID(c),
+		"updated_at":  time.Now(),
+	}
+	_, err = config.DB.Collection("kehadiran").UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), bson.M{"$set": update})
+	if mongo.IsDuplicateKeyError(err) {
+		return c.Status(409).JSON(fiber.Map{"error": "User already has kehadiran for this kegiatan"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update kehadiran"})
+	}
+	return c.JSON(fiber.Map{"message": "Kehadiran updated"})
+}
+
+// @Security BearerAuth
+// PatchKehadiran godoc
+// @Summary Partially update kehadiran
+// @Description Hanya field yang dikirim yang diubah (JSON Merge Patch), field bernilai null dikosongkan
+// @Tags Kehadiran
+// @Accept json
+// @Produce json
+// @Param id path string true "Kehadiran ID"
+// @Param kehadiran body model.Kehadiran true "Field kehadiran yang diubah"
+// @Success 200 {object} model.Kehadiran
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/{id} [patch]
+func PatchKehadiran(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var kehadiran model.Kehadiran
+	err = config.DB.Collection("kehadiran").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&kehadiran)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Kehadiran not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+	update, err := applyMergePatch(c.Body(), &kehadiran, "metode", "lokasi_cek", "client_id", "verifikasi", "catatan_verifikasi", "verified_by", "verified_at")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := kehadiranValidate.Struct(kehadiran); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	_, userChanged := update["user_id"]
+	_, kegiatanChanged := update["kegiatan_id"]
+	if userChanged || kegiatanChanged {
+		msg, err := validateKehadiranRefs(ctx, kehadiran)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to validate kehadiran"})
+		}
+		if msg != "" {
+			return c.Status(400).JSON(fiber.Map{"error": msg})
+		}
+	}
+	kehadiran.ID = id
+	kehadiran.UpdatedBy = utils.GetUserID(c)
+	kehadiran.UpdatedAt = time.Now()
+	update["updated_by"] = kehadiran.UpdatedBy
+	update["updated_at"] = kehadiran.UpdatedAt
+	res, err := config.DB.Collection("kehadiran").UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), bson.M{"$set": update})
+	if mongo.IsDuplicateKeyError(err) {
+		return c.Status(409).JSON(fiber.Map{"error": "User already has kehadiran for this kegiatan"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update kehadiran"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Kehadiran not found"})
+	}
+	return c.JSON(kehadiran)
+}
+
+// @Security BearerAuth
+// DeleteKehadiran godoc
+// @Summary Delete kehadiran (soft delete)
+// @Tags Kehadiran
+// @Produce json
+// @Param id path string true "Kehadiran ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/{id} [delete]
+func DeleteKehadiran(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// Soft delete: data dipindah ke tempat sampah dan masih bisa di-restore
+	update := bson.M{"$set": bson.M{
+		"deleted_at": time.Now(),
+		"deleted_by": utils.GetUserID(c),
+	}}
+	res, err := config.DB.Collection("kehadiran").UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete kehadiran"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Kehadiran not found"})
+	}
+	return c.JSON(fiber.Map{"message": "Kehadiran deleted"})
+}
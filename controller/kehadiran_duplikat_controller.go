@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type KehadiranDuplikat struct {
+	UserID     primitive.ObjectID `json:"user_id"`
+	KegiatanID primitive.ObjectID `json:"kegiatan_id"`
+	Jumlah     int                `json:"jumlah"`
+	Kehadiran  []model.Kehadiran  `json:"kehadiran"`
+}
+
+type MergeKehadiranRequest struct {
+	KegiatanID string `json:"kegiatan_id"`
+}
+
+type MergeKehadiranResponse struct {
+	Grup      int      `json:"grup"`
+	Dihapus   int      `json:"dihapus"`
+	Disimpan  []string `json:"disimpan"`
+	IndexAman bool     `json:"index_aman"`
+}
+
+// Urutan prioritas saat memilih kehadiran yang dipertahankan, makin kecil makin diutamakan
+var prioritasStatusKehadiran = map[string]int{
+	model.StatusHadir:     0,
+	model.StatusTerlambat: 1,
+	model.StatusIzin:      2,
+	model.StatusSakit:     2,
+	model.StatusAlpa:      3,
+	model.StatusTidak:     3,
+}
+
+// findKehadiranDuplikat mengelompokkan kehadiran aktif dengan user dan kegiatan yang sama
+func findKehadiranDuplikat(ctx context.Context, match bson.M) ([]KehadiranDuplikat, error) {
+	pipeline := []bson.M{
+		{"$match": notDeleted(match)},
+		{"$sort": bson.M{"created_at": 1}},
+		{
+			"$group": bson.M{
+				"_id":       bson.M{"user_id": "$user_id", "kegiatan_id": "$kegiatan_id"},
+				"jumlah":    bson.M{"$sum": 1},
+				"kehadiran": bson.M{"$push": "$$ROOT"},
+			},
+		},
+		{"$match": bson.M{"jumlah": bson.M{"$gt": 1}}},
+		{"$sort": bson.M{"_id.kegiatan_id": 1, "_id.user_id": 1}},
+	}
+	cursor, err := config.DB.Collection("kehadiran").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		ID struct {
+			UserID     primitive.ObjectID `bson:"user_id"`
+			KegiatanID primitive.ObjectID `bson:"kegiatan_id"`
+		} `bson:"_id"`
+		Jumlah    int               `bson:"jumlah"`
+		Kehadiran []model.Kehadiran `bson:"kehadiran"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	results := make([]KehadiranDuplikat, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, KehadiranDuplikat{
+			UserID:     row.ID.UserID,
+			KegiatanID: row.ID.KegiatanID,
+			Jumlah:     row.Jumlah,
+			Kehadiran:  row.Kehadiran,
+		})
+	}
+	return results, nil
+}
+
+// pilihKehadiranUtama memilih kehadiran dengan status terbaik, data yang lebih dulu dibuat
+// menang jika statusnya setara (data sudah diurutkan created_at)
+func pilihKehadiranUtama(kehadirans []model.Kehadiran) int {
+	utama := 0
+	for i, k := range kehadirans {
+		if prioritasStatusKehadiran[k.Status] < prioritasStatusKehadiran[kehadirans[utama].Status] {
+			utama = i
+		}
+	}
+	return utama
+}
+
+// GetKehadiranDuplikat godoc
+// @Summary Detect duplicate kehadiran
+// @Description Daftar user yang memiliki lebih dari satu kehadiran aktif pada kegiatan yang sama
+// @Tags Kehadiran
+// @Produce json
+// @Param kegiatan_id query string false "Filter kegiatan"
+// @Success 200 {array} KehadiranDuplikat
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/duplicates [get]
+// @Security BearerAuth
+func GetKehadiranDuplikat(c *fiber.Ctx) error {
+	match := bson.M{}
+	if kegiatanID := c.Query("kegiatan_id"); kegiatanID != "" {
+		objID, err := primitive.ObjectIDFromHex(kegiatanID)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid kegiatan_id"})
+		}
+		match["kegiatan_id"] = objID
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results, err := findKehadiranDuplikat(ctx, match)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch duplicate kehadiran"})
+	}
+	return c.JSON(results)
+}
+
+// MergeKehadiranDuplikat godoc
+// @Summary Merge duplicate kehadiran
+// @Description Mempertahankan satu kehadiran per user per kegiatan (hadir > terlambat > izin/sakit > alpa, lalu yang paling awal dibuat).
+// @Description Sisanya dipindahkan ke trash, kemudian index unik kehadiran dibuat ulang.
+// @Tags Kehadiran
+// @Accept json
+// @Produce json
+// @Param body body MergeKehadiranRequest false "Batasi ke satu kegiatan"
+// @Success 200 {object} MergeKehadiranResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/duplicates/merge [post]
+// @Security BearerAuth
+func MergeKehadiranDuplikat(c *fiber.Ctx) error {
+	var input MergeKehadiranRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+	match := bson.M{}
+	if input.KegiatanID != "" {
+		objID, err := primitive.ObjectIDFromHex(input.KegiatanID)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid kegiatan_id"})
+		}
+		match["kegiatan_id"] = objID
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	groups, err := findKehadiranDuplikat(ctx, match)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch duplicate kehadiran"})
+	}
+
+	adminID := utils.GetUserID(c)
+	now := time.Now()
+	result := MergeKehadiranResponse{Grup: len(groups), Disimpan: []string{}}
+	for _, group := range groups {
+		utama := pilihKehadiranUtama(group.Kehadiran)
+		for i, k := range group.Kehadiran {
+			if i == utama {
+				continue
+			}
+			objID, err := primitive.ObjectIDFromHex(k.ID)
+			if err != nil {
+				continue
+			}
+			update := bson.M{"$set": bson.M{
+				"deleted_at":  config.WaktuHapusKehadiran(now, i),
+				"deleted_by":  adminID,
+				"merged_into": group.Kehadiran[utama].ID,
+			}}
+			res, err := config.DB.Collection("kehadiran").UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), update)
+			if err != nil {
+				return c.Status(500).JSON(fiber.Map{"error": "Failed to merge duplicate kehadiran"})
+			}
+			result.Dihapus += int(res.ModifiedCount)
+		}
+		result.Disimpan = append(result.Disimpan, group.Kehadiran[utama].ID)
+	}
+
+	// Index unik gagal dibuat selama masih ada duplikat, coba lagi setelah dibersihkan
+	config.EnsureIndexes(config.DB)
+	sisa, err := findKehadiranDuplikat(ctx, bson.M{})
+	result.IndexAman = err == nil && len(sisa) == 0
+	return c.JSON(result)
+}
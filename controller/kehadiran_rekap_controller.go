@@ -0,0 +1,274 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RiwayatKehadiran adalah satu baris hasil kehadiranPipeline untuk seorang user. ID kosong
+// menandakan kegiatan wajib yang tidak memiliki catatan kehadiran dan dihitung alpa.
+type RiwayatKehadiran struct {
+	ID              string             `json:"id,omitempty" bson:"_id"`
+	KegiatanID      primitive.ObjectID `json:"kegiatan_id" bson:"kegiatan_id"`
+	KegiatanNama    string             `json:"kegiatan_nama" bson:"kegiatan_nama"`
+	KegiatanTanggal string             `json:"kegiatan_tanggal" bson:"kegiatan_tanggal"`
+	UKM             string             `json:"ukm" bson:"ukm"`
+	Status          string             `json:"status" bson:"status"`
+	WaktuCek        string             `json:"waktu_cek" bson:"waktu_cek"`
+	Metode          string             `json:"metode,omitempty" bson:"metode"`
+	Verifikasi      string             `json:"verifikasi,omitempty" bson:"verifikasi"`
+}
+
+type SemesterKehadiran struct {
+	Semester         string           `json:"semester"`
+	Total            int              `json:"total"`
+	PerStatus        map[string]int64 `json:"per_status"`
+	TingkatKehadiran float64          `json:"tingkat_kehadiran"`
+}
+
+type RekapKehadiranResponse struct {
+	UserID           primitive.ObjectID  `json:"user_id"`
+	Nama             string              `json:"nama"`
+	Total            int                 `json:"total"`
+	PerStatus        map[string]int64    `json:"per_status"`
+	PendingReview    int                 `json:"pending_review"`
+	TingkatKehadiran float64             `json:"tingkat_kehadiran"`
+	StreakSaatIni    int                 `json:"streak_saat_ini"`
+	StreakTerpanjang int                 `json:"streak_terpanjang"`
+	PerSemester      []SemesterKehadiran `json:"per_semester"`
+	Terlewat         []RiwayatKehadiran  `json:"terlewat"`
+	Riwayat          []RiwayatKehadiran  `json:"riwayat"`
+}
+
+// hitungRekapKehadiran merangkum riwayat kehadiran yang sudah urut dari kegiatan terlama.
+// Check-in yang menunggu verifikasi belum dihitung hadir, sedangkan izin dan sakit tidak
+// memutus maupun menambah streak, sama seperti perhitungan tingkatKehadiran.
+func hitungRekapKehadiran(riwayat []RiwayatKehadiran) RekapKehadiranResponse {
+	rekap := RekapKehadiranResponse{
+		Total:       len(riwayat),
+		PerStatus:   map[string]int64{},
+		PerSemester: []SemesterKehadiran{},
+		Terlewat:    []RiwayatKehadiran{},
+	}
+	perSemester := map[string]*SemesterKehadiran{}
+	streak := 0
+	for _, r := range riwayat {
+		pending := r.Verifikasi == model.VerifikasiPending && isCheckin(r.Status)
+		if pending {
+			rekap.PendingReview++
+		} else {
+			rekap.PerStatus[r.Status]++
+		}
+
+		if t, err := utils.ParseTanggal(r.KegiatanTanggal); err == nil {
+			nama := utils.Semester(t)
+			semester, ok := perSemester[nama]
+			if !ok {
+				semester = &SemesterKehadiran{Semester: nama, PerStatus: map[string]int64{}}
+				perSemester[nama] = semester
+			}
+			semester.Total++
+			if !pending {
+				semester.PerStatus[r.Status]++
+			}
+		}
+
+		switch {
+		case pending:
+		case isCheckin(r.Status):
+			streak++
+			if streak > rekap.StreakTerpanjang {
+				rekap.StreakTerpanjang = streak
+			}
+		case r.Status == model.StatusAlpa || r.Status == model.StatusTidak:
+			streak = 0
+			rekap.Terlewat = append(rekap.Terlewat, r)
+		}
+	}
+	rekap.StreakSaatIni = streak
+	rekap.TingkatKehadiran = tingkatKehadiran(rekap.PerStatus)
+	for _, semester := range perSemester {
+		semester.TingkatKehadiran = tingkatKehadiran(semester.PerStatus)
+		rekap.PerSemester = append(rekap.PerSemester, *semester)
+	}
+	// Nama semester diawali tahun akademik sehingga urutan string sama dengan urutan waktu
+	sort.Slice(rekap.PerSemester, func(i, j int) bool {
+		return rekap.PerSemester[i].Semester > rekap.PerSemester[j].Semester
+	})
+	return rekap
+}
+
+// kegiatanTanpaKehadiran mencari kegiatan approved yang sudah lewat jendela check-in dan wajib
+// diikuti user (aturan pesertaKegiatan: anggota UKM tuan rumah/co-host atau panitia), tetapi
+// belum memiliki catatan kehadiran sama sekali. Kegiatan tersebut dihitung alpa.
+func kegiatanTanpaKehadiran(ctx context.Context, userID primitive.ObjectID, user model.User, tercatat map[primitive.ObjectID]bool) ([]RiwayatKehadiran, error) {
+	panitiaIDs, err := config.DB.Collection("panitia").Distinct(ctx, "kegiatan_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	if panitiaIDs == nil {
+		panitiaIDs = []interface{}{}
+	}
+	filter := notDeleted(approvedOnly(bson.M{"$or": []bson.M{
+		{"kategori": user.UKM},
+		{"co_hosts": user.UKM},
+		{"_id": bson.M{"$in": panitiaIDs}},
+	}}))
+	cursor, err := config.DB.Collection("kegiatan").Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var kegiatans []model.Kegiatan
+	if err := cursor.All(ctx, &kegiatans); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	hasil := []RiwayatKehadiran{}
+	for _, kegiatan := range kegiatans {
+		if tercatat[kegiatan.ID] || !checkinDitutup(kegiatan, now) {
+			continue
+		}
+		// Kegiatan sebelum user terdaftar tidak dihitung
+		if t, err := utils.ParseTanggal(kegiatan.Tanggal); err == nil && !user.CreatedAt.IsZero() && t.Before(user.CreatedAt) {
+			continue
+		}
+		hasil = append(hasil, RiwayatKehadiran{
+			KegiatanID:      kegiatan.ID,
+			KegiatanNama:    kegiatan.Judul,
+			KegiatanTanggal: kegiatan.Tanggal,
+			UKM:             kegiatan.Kategori,
+			Status:          model.StatusAlpa,
+		})
+	}
+	return hasil, nil
+}
+
+// rekapKehadiranUser mengambil riwayat kehadiran user lewat kehadiranPipeline, menambahkan
+// kegiatan wajib yang tidak memiliki catatan kehadiran, lalu merangkumnya
+func rekapKehadiranUser(ctx context.Context, userID primitive.ObjectID, user model.User) (RekapKehadiranResponse, error) {
+	var rekap RekapKehadiranResponse
+	cursor, err := config.DB.Collection("kehadiran").Aggregate(ctx, kehadiranPipeline(notDeleted(bson.M{"user_id": userID})))
+	if err != nil {
+		return rekap, err
+	}
+	riwayat := []RiwayatKehadiran{}
+	if err := cursor.All(ctx, &riwayat); err != nil {
+		return rekap, err
+	}
+	tercatat := make(map[primitive.ObjectID]bool, len(riwayat))
+	for _, r := range riwayat {
+		tercatat[r.KegiatanID] = true
+	}
+	terlewat, err := kegiatanTanpaKehadiran(ctx, userID, user, tercatat)
+	if err != nil {
+		return rekap, err
+	}
+	riwayat = append(riwayat, terlewat...)
+	// Tanggal kegiatan bisa berbeda format, urutkan setelah di-parse
+	waktu := func(r RiwayatKehadiran) time.Time {
+		t, _ := utils.ParseTanggal(r.KegiatanTanggal)
+		return t
+	}
+	sort.SliceStable(riwayat, func(i, j int) bool {
+		return waktu(riwayat[i]).Before(waktu(riwayat[j]))
+	})
+
+	rekap = hitungRekapKehadiran(riwayat)
+	rekap.UserID, rekap.Nama = userID, user.Nama
+	// Riwayat dan kegiatan terlewat ditampilkan dari yang terbaru
+	for i, j := 0, len(riwayat)-1; i < j; i, j = i+1, j-1 {
+		riwayat[i], riwayat[j] = riwayat[j], riwayat[i]
+	}
+	for i, j := 0, len(rekap.Terlewat)-1; i < j; i, j = i+1, j-1 {
+		rekap.Terlewat[i], rekap.Terlewat[j] = rekap.Terlewat[j], rekap.Terlewat[i]
+	}
+	rekap.Riwayat = riwayat
+	return rekap, nil
+}
+
+// GetMyKehadiran godoc
+// @Summary Get attendance recap of the logged-in user
+// @Description Tingkat kehadiran per semester, jumlah per status, streak hadir berturut-turut, kegiatan yang terlewat (alpa), dan riwayat kehadiran.
+// @Description Kegiatan approved yang sudah selesai dan wajib diikuti (anggota UKM penyelenggara atau panitia) tanpa catatan kehadiran dihitung alpa.
+// @Tags Kehadiran
+// @Produce json
+// @Success 200 {object} RekapKehadiranResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /me/kehadiran [get]
+// @Security BearerAuth
+func GetMyKehadiran(c *fiber.Ctx) error {
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var user model.User
+	err = config.DB.Collection("users").FindOne(ctx, notDeleted(bson.M{"_id": userID})).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(401).JSON(fiber.Map{"error": "Unauthorized"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch user"})
+	}
+	rekap, err := rekapKehadiranUser(ctx, userID, user)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+	return c.JSON(rekap)
+}
+
+// GetUserKehadiran godoc
+// @Summary Get attendance recap of a member
+// @Description Hanya dapat dilihat oleh user itu sendiri dan admin UKM-nya. Perhitungan sama dengan GET /me/kehadiran.
+// @Tags Kehadiran
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} RekapKehadiranResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users/{id}/kehadiran [get]
+// @Security BearerAuth
+func GetUserKehadiran(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var user model.User
+	err = config.DB.Collection("users").FindOne(ctx, notDeleted(bson.M{"_id": userID})).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "User not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch user"})
+	}
+	if userID.Hex() != utils.GetUserID(c) {
+		allowed, err := canManageUKM(ctx, c, user.UKM)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+		}
+		if !allowed {
+			return c.Status(403).JSON(fiber.Map{"error": "Only the member and admins of their UKM can view this recap"})
+		}
+	}
+	rekap, err := rekapKehadiranUser(ctx, userID, user)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+	return c.JSON(rekap)
+}
@@ -0,0 +1,237 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Hasil sinkronisasi selain created/unchanged/failed milik kehadiran massal
+const (
+	SyncDuplikat = "duplicate"
+	SyncKonflik  = "conflict"
+)
+
+// Toleransi selisih jam perangkat panitia terhadap server
+const toleransiJamPerangkat = 5 * time.Minute
+
+type SyncKehadiranItem struct {
+	ClientID string `json:"client_id" validate:"required,max=64"`
+	UserID   string `json:"user_id" validate:"required"`
+	// Status dari perangkat hanya informasi, status tersimpan dihitung ulang dari waktu_cek
+	Status   string `json:"status" validate:"omitempty,oneof=hadir terlambat"`
+	WaktuCek string `json:"waktu_cek" validate:"required"`
+}
+
+type SyncKehadiranRequest struct {
+	Kehadiran []SyncKehadiranItem `json:"kehadiran" validate:"required,min=1,max=500,dive"`
+}
+
+type SyncKehadiranHasil struct {
+	ClientID     string `json:"client_id"`
+	UserID       string `json:"user_id"`
+	Hasil        string `json:"hasil"`
+	KehadiranID  string `json:"kehadiran_id,omitempty"`
+	Status       string `json:"status,omitempty"`
+	StatusServer string `json:"status_server,omitempty"`
+	Pesan        string `json:"pesan,omitempty"`
+}
+
+type SyncKehadiranResponse struct {
+	Diterima     int                  `json:"diterima"`
+	Duplikat     int                  `json:"duplikat"`
+	TidakBerubah int                  `json:"tidak_berubah"`
+	Konflik      int                  `json:"konflik"`
+	Gagal        int                  `json:"gagal"`
+	Hasil        []SyncKehadiranHasil `json:"hasil"`
+}
+
+// kehadiranPerClientID mengambil kehadiran yang pernah disinkronkan dengan client_id tersebut,
+// termasuk yang sudah dihapus agar sinkronisasi ulang tidak menghidupkannya kembali
+func kehadiranPerClientID(ctx context.Context, clientIDs []string) (map[string]model.Kehadiran, error) {
+	cursor, err := config.DB.Collection("kehadiran").Find(ctx, bson.M{"client_id": bson.M{"$in": clientIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var kehadirans []model.Kehadiran
+	if err := cursor.All(ctx, &kehadirans); err != nil {
+		return nil, err
+	}
+	result := make(map[string]model.Kehadiran, len(kehadirans))
+	for _, k := range kehadirans {
+		result[k.ClientID] = k
+	}
+	return result, nil
+}
+
+// SyncKehadiran godoc
+// @Summary Sync check-ins captured offline
+// @Description Mengunggah check-in yang dicatat aplikasi panitia saat offline. Setiap data wajib memiliki client_id unik buatan aplikasi
+// @Description sehingga unggahan ulang aman (hasil duplicate). Jika server sudah memiliki status berbeda untuk user tersebut, data tidak
+// @Description ditimpa dan dilaporkan sebagai conflict beserta status_server. waktu_cek harus berada di jendela check-in kegiatan,
+// @Description status hadir/terlambat dihitung server dari waktu_cek seperti check-in mandiri.
+// @Tags Kehadiran
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param body body SyncKehadiranRequest true "Check-in offline dengan waktu_cek asli (RFC3339)"
+// @Success 200 {object} SyncKehadiranResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/kehadiran/sync [post]
+// @Security BearerAuth
+func SyncKehadiran(c *fiber.Ctx) error {
+	var input SyncKehadiranRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := kehadiranValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only organizers can sync kehadiran for this kegiatan"})
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(409).JSON(fiber.Map{"error": "Kegiatan is not approved yet"})
+	}
+
+	userIDs := make([]string, 0, len(input.Kehadiran))
+	clientIDs := make([]string, 0, len(input.Kehadiran))
+	for _, item := range input.Kehadiran {
+		userIDs = append(userIDs, item.UserID)
+		clientIDs = append(clientIDs, item.ClientID)
+	}
+	userAda, err := userTerdaftar(ctx, userIDs)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch users"})
+	}
+	existing, err := kehadiranPerUser(ctx, kegiatan.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+	synced, err := kehadiranPerClientID(ctx, clientIDs)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+
+	organizerID := utils.GetUserID(c)
+	now := time.Now()
+	response := SyncKehadiranResponse{Hasil: []SyncKehadiranHasil{}}
+	for _, item := range input.Kehadiran {
+		hasil := SyncKehadiranHasil{ClientID: item.ClientID, UserID: item.UserID, Status: item.Status}
+		if lama, found := synced[item.ClientID]; found {
+			if lama.UserID.Hex() == item.UserID && lama.KegiatanID == kegiatan.ID {
+				hasil.Hasil, hasil.KehadiranID = SyncDuplikat, lama.ID
+			} else {
+				hasil.Hasil, hasil.Pesan = BulkGagal, "client_id is already used by another kehadiran"
+			}
+			response.Hasil = append(response.Hasil, hasil)
+			continue
+		}
+		waktu, err := time.Parse(time.RFC3339, item.WaktuCek)
+		switch {
+		case !userAda[item.UserID]:
+			hasil.Hasil, hasil.Pesan = BulkGagal, "user not found"
+		case err != nil:
+			hasil.Hasil, hasil.Pesan = BulkGagal, "waktu_cek must be in RFC3339 format"
+		case waktu.After(now.Add(toleransiJamPerangkat)):
+			hasil.Hasil, hasil.Pesan = BulkGagal, "waktu_cek is in the future, check the device clock"
+		}
+		if hasil.Hasil != "" {
+			response.Hasil = append(response.Hasil, hasil)
+			continue
+		}
+		// Jendela check-in dan status terlambat mengikuti aturan check-in mandiri
+		status, msg := statusCheckin(kegiatan, waktu)
+		if msg != "" {
+			hasil.Hasil, hasil.Pesan = BulkGagal, "waktu_cek is outside the check-in window: "+msg
+			response.Hasil = append(response.Hasil, hasil)
+			continue
+		}
+		item.Status, hasil.Status = status, status
+
+		if lama, found := existing[item.UserID]; found {
+			hasil.KehadiranID, hasil.StatusServer = lama.ID, lama.Status
+			hasil.Hasil = BulkTidakBerubah
+			if lama.Status != item.Status {
+				hasil.Hasil = SyncKonflik
+			}
+			response.Hasil = append(response.Hasil, hasil)
+			continue
+		}
+
+		// user_id sudah dipastikan valid oleh userTerdaftar
+		userID, _ := primitive.ObjectIDFromHex(item.UserID)
+		kehadiran := model.Kehadiran{
+			UserID:     userID,
+			KegiatanID: kegiatan.ID,
+			Status:     item.Status,
+			WaktuCek:   waktu.In(utils.Location).Format(time.RFC3339),
+			Metode:     model.MetodeOffline,
+			ClientID:   item.ClientID,
+			CreatedBy:  organizerID,
+			CreatedAt:  now,
+			UpdatedBy:  organizerID,
+			UpdatedAt:  now,
+		}
+		kehadiran, created, err := simpanKehadiran(ctx, kehadiran)
+		switch {
+		case err == mongo.ErrNoDocuments:
+			// Bentrok index client_id: data yang sama sedang disinkronkan perangkat lain,
+			// unggah ulang akan menghasilkan duplicate
+			hasil.Hasil, hasil.Pesan = BulkGagal, "kehadiran is being synced by another request, please retry"
+		case err != nil:
+			hasil.Hasil, hasil.Pesan = BulkGagal, "failed to save kehadiran"
+		case created:
+			hasil.Hasil, hasil.KehadiranID = BulkDibuat, kehadiran.ID
+			existing[item.UserID] = kehadiran
+			synced[item.ClientID] = kehadiran
+		default:
+			// Tersimpan lebih dulu oleh check-in lain di antara pengecekan dan penyimpanan
+			hasil.KehadiranID, hasil.StatusServer = kehadiran.ID, kehadiran.Status
+			hasil.Hasil = BulkTidakBerubah
+			if kehadiran.Status != item.Status {
+				hasil.Hasil = SyncKonflik
+			}
+			existing[item.UserID] = kehadiran
+		}
+		response.Hasil = append(response.Hasil, hasil)
+	}
+
+	for _, hasil := range response.Hasil {
+		switch hasil.Hasil {
+		case BulkDibuat:
+			response.Diterima++
+		case SyncDuplikat:
+			response.Duplikat++
+		case BulkTidakBerubah:
+			response.TidakBerubah++
+		case SyncKonflik:
+			response.Konflik++
+		case BulkGagal:
+			response.Gagal++
+		}
+	}
+	return c.JSON(response)
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type VerifikasiKehadiranRequest struct {
+	Status  string `json:"status" validate:"required,oneof=approved rejected"`
+	Catatan string `json:"catatan" validate:"required_if=Status rejected,max=500"`
+}
+
+// GetKehadiranFlagged godoc
+// @Summary Get flagged kehadiran for review
+// @Description Kehadiran yang check-in di luar radius lokasi kegiatan dan pengajuan izin/sakit. Default hanya yang masih pending.
+// @Description Admin hanya melihat kehadiran kegiatan yang diselenggarakan UKM-nya (termasuk co-host).
+// @Tags Kehadiran
+// @Produce json
+// @Param verifikasi query string false "Filter status verifikasi (pending, approved, rejected)"
+// @Param jenis query string false "Filter jenis (lokasi, izin)"
+// @Param kegiatan_id query string false "Filter kegiatan"
+// @Success 200 {array} model.Kehadiran
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/flagged [get]
+// @Security BearerAuth
+func GetKehadiranFlagged(c *fiber.Ctx) error {
+	filter := notDeleted(bson.M{"verifikasi": c.Query("verifikasi", model.VerifikasiPending)})
+	if kegiatanID := c.Query("kegiatan_id"); kegiatanID != "" {
+		objID, err := primitive.ObjectIDFromHex(kegiatanID)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid kegiatan_id"})
+		}
+		filter["kegiatan_id"] = objID
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// Admin hanya melihat kehadiran kegiatan yang diselenggarakan UKM-nya, Semua UKM tidak dibatasi
+	dikelola, ok, err := filterKegiatanDikelola(ctx, c)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !ok {
+		return c.Status(403).JSON(fiber.Map{"error": "Only UKM admins can review flagged kehadiran"})
+	}
+	if len(dikelola) > 0 {
+		kegiatanIDs, err := config.DB.Collection("kegiatan").Distinct(ctx, "_id", dikelola)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+		}
+		filter["$and"] = []bson.M{{"kegiatan_id": bson.M{"$in": kegiatanIDs}}}
+	}
+	switch c.Query("jenis") {
+	case "lokasi":
+		filter["lokasi_cek"] = bson.M{"$exists": true}
+	case "izin":
+		filter["alasan"] = bson.M{"$exists": true}
+	}
+	pipeline := append(kehadiranPipeline(filter, "lokasi_cek", "alasan", "dokumen_url"), bson.M{"$sort": bson.M{"waktu_cek": -1}})
+	cursor, err := config.DB.Collection("kehadiran").Aggregate(ctx, pipeline)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+	kehadirans := []bson.M{}
+	if err := cursor.All(ctx, &kehadirans); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode kehadiran"})
+	}
+	return c.JSON(kehadirans)
+}
+
+// VerifikasiKehadiran godoc
+// @Summary Approve or reject a flagged kehadiran
+// @Description Berlaku untuk check-in di luar radius maupun pengajuan izin/sakit. Kehadiran yang ditolak diubah menjadi alpa dan peserta menerima notifikasi.
+// @Tags Kehadiran
+// @Accept json
+// @Produce json
+// @Param id path string true "Kehadiran ID"
+// @Param body body VerifikasiKehadiranRequest true "Keputusan verifikasi"
+// @Success 200 {object} model.Kehadiran
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/{id}/verifikasi [put]
+// @Security BearerAuth
+func VerifikasiKehadiran(c *fiber.Ctx) error {
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	var input VerifikasiKehadiranRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := kehadiranValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var kehadiran model.Kehadiran
+	err = config.DB.Collection("kehadiran").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&kehadiran)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Kehadiran not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+	if kehadiran.Verifikasi != model.VerifikasiPending {
+		return c.Status(409).JSON(fiber.Map{"error": "Kehadiran is not waiting for verification"})
+	}
+	kegiatan, err := findKegiatanByID(ctx, kehadiran.KegiatanID.Hex())
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can verify this kehadiran"})
+	}
+
+	now := time.Now()
+	pengajuanIzin := kehadiran.Status == model.StatusIzin || kehadiran.Status == model.StatusSakit
+	kehadiran.Verifikasi = input.Status
+	kehadiran.CatatanVerifikasi = input.Catatan
+	kehadiran.VerifiedBy = utils.GetUserID(c)
+	kehadiran.VerifiedAt = &now
+	kehadiran.UpdatedBy = kehadiran.VerifiedBy
+	kehadiran.UpdatedAt = now
+	if input.Status == model.VerifikasiRejected {
+		kehadiran.Status = model.StatusAlpa
+	}
+	update := bson.M{"$set": bson.M{
+		"status":             kehadiran.Status,
+		"verifikasi":         kehadiran.Verifikasi,
+		"catatan_verifikasi": kehadiran.CatatanVerifikasi,
+		"verified_by":        kehadiran.VerifiedBy,
+		"verified_at":        now,
+		"updated_by":         kehadiran.UpdatedBy,
+		"updated_at":         now,
+	}}
+	// Filter status lama mencegah keputusan ganda dari request bersamaan
+	filter := notDeleted(bson.M{"_id": objID, "verifikasi": model.VerifikasiPending})
+	res, err := config.DB.Collection("kehadiran").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to verify kehadiran"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "Kehadiran status has changed, please reload"})
+	}
+
+	userID := kehadiran.UserID
+	if pengajuanIzin {
+		judul, pesan := "Izin disetujui", fmt.Sprintf("Pengajuan izin Anda pada kegiatan \"%s\" telah disetujui", kegiatan.Judul)
+		if input.Status == model.VerifikasiRejected {
+			judul, pesan = "Izin ditolak", fmt.Sprintf("Pengajuan izin Anda pada kegiatan \"%s\" ditolak: %s", kegiatan.Judul, input.Catatan)
+		}
+		notify(ctx, []primitive.ObjectID{userID}, "izin_"+input.Status, judul, pesan, kegiatan.ID)
+	} else if input.Status == model.VerifikasiRejected {
+		notify(ctx, []primitive.ObjectID{userID}, "kehadiran_rejected", "Kehadiran ditolak",
+			fmt.Sprintf("Check-in Anda pada kegiatan \"%s\" ditolak: %s", kegiatan.Judul, input.Catatan), kegiatan.ID)
+	}
+	return c.JSON(kehadiran)
+}
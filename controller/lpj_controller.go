@@ -0,0 +1,664 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var lpjValidate = validator.New()
+
+type LPJRequest struct {
+	Narasi            string                    `json:"narasi" validate:"required"`
+	RealisasiAnggaran []model.RealisasiAnggaran `json:"realisasi_anggaran" validate:"dive"`
+}
+
+type ReviewLPJRequest struct {
+	Status   string `json:"status" validate:"required,oneof=approved revision"`
+	Komentar string `json:"komentar" validate:"required_if=Status revision"`
+}
+
+type OverdueLPJ struct {
+	KegiatanID    string `json:"kegiatan_id"`
+	Judul         string `json:"judul"`
+	Kategori      string `json:"kategori"`
+	Tanggal       string `json:"tanggal"`
+	BatasLPJ      string `json:"batas_lpj"`
+	HariTerlambat int    `json:"hari_terlambat"`
+	StatusLPJ     string `json:"status_lpj"`
+}
+
+// lpjDeadline membaca batas pengumpulan LPJ dari LPJ_DEADLINE_DAYS (default 14 hari)
+func lpjDeadline() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("LPJ_DEADLINE_DAYS"))
+	if err != nil || days <= 0 {
+		days = 14
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// hitungRingkasanKehadiran merangkum data kehadiran sebuah kegiatan per status
+func hitungRingkasanKehadiran(ctx context.Context, kegiatanID primitive.ObjectID) (model.RingkasanKehadiran, error) {
+	ringkasan := model.RingkasanKehadiran{PerStatus: map[string]int64{}}
+	pipeline := []bson.M{
+		{"$match": notDeleted(bson.M{"kegiatan_id": kegiatanID})},
+		{"$group": bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}},
+	}
+	cursor, err := config.DB.Collection("kehadiran").Aggregate(ctx, pipeline)
+	if err != nil {
+		return ringkasan, err
+	}
+	var results []struct {
+		Status string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return ringkasan, err
+	}
+	for _, r := range results {
+		ringkasan.PerStatus[r.Status] = r.Count
+		ringkasan.Total += r.Count
+	}
+	ringkasan.TingkatKehadiran = tingkatKehadiran(ringkasan.PerStatus)
+	return ringkasan, nil
+}
+
+func hitungTotalAnggaran(items []model.RealisasiAnggaran) (float64, float64) {
+	var anggaran, realisasi float64
+	for _, item := range items {
+		anggaran += item.Anggaran
+		realisasi += item.Realisasi
+	}
+	return anggaran, realisasi
+}
+
+// kegiatanSelesai mengecek apakah jadwal kegiatan sudah berakhir. Kegiatan dengan format
+// tanggal yang tidak dikenal dianggap selesai agar data lama tetap bisa dilaporkan.
+func kegiatanSelesai(kegiatan model.Kegiatan, now time.Time) bool {
+	_, selesai, err := jadwalKegiatan(kegiatan)
+	return err != nil || now.After(selesai)
+}
+
+// findLPJ mengambil LPJ milik kegiatan. Ringkasan kehadiran dihitung ulang selama
+// LPJ masih bisa diubah, dan dibekukan sejak LPJ di-submit.
+func findLPJ(ctx context.Context, kegiatanID primitive.ObjectID) (model.LPJ, error) {
+	var lpj model.LPJ
+	err := config.DB.Collection("lpj").FindOne(ctx, bson.M{"kegiatan_id": kegiatanID}).Decode(&lpj)
+	if err != nil {
+		return lpj, err
+	}
+	if lpj.Status == model.LPJDraft || lpj.Status == model.LPJRevision {
+		lpj.RingkasanKehadiran, err = hitungRingkasanKehadiran(ctx, kegiatanID)
+	}
+	return lpj, err
+}
+
+// GetLPJ godoc
+// @Summary Get LPJ of a kegiatan
+// @Description Hanya reviewer dan admin UKM penyelenggara kegiatan
+// @Tags LPJ
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} model.LPJ
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/lpj [get]
+// @Security BearerAuth
+func GetLPJ(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	if utils.GetUserRole(c) != "reviewer" {
+		allowed, err := canManageKegiatan(ctx, c, kegiatan)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+		}
+		if !allowed {
+			return c.Status(403).JSON(fiber.Map{"error": "Only reviewers and admins of the hosting UKMs can view this LPJ"})
+		}
+	}
+	lpj, err := findLPJ(ctx, kegiatan.ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "LPJ not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch LPJ"})
+	}
+	return c.JSON(lpj)
+}
+
+// CreateLPJ godoc
+// @Summary Create LPJ for a kegiatan
+// @Description Ringkasan kehadiran diambil otomatis dari data kehadiran kegiatan. Jika realisasi_anggaran kosong, diisi dari anggaran kegiatan.
+// @Tags LPJ
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param lpj body LPJRequest true "LPJ Data"
+// @Success 201 {object} model.LPJ
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/lpj [post]
+// @Security BearerAuth
+func CreateLPJ(c *fiber.Ctx) error {
+	var input LPJRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := lpjValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can manage this LPJ"})
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(409).JSON(fiber.Map{"error": "LPJ can only be created for approved kegiatan"})
+	}
+	if !kegiatanSelesai(kegiatan, time.Now()) {
+		return c.Status(409).JSON(fiber.Map{"error": "LPJ can only be created after the kegiatan has ended"})
+	}
+	ringkasan, err := hitungRingkasanKehadiran(ctx, kegiatan.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to summarize kehadiran"})
+	}
+
+	now := time.Now()
+	lpj := model.LPJ{
+		KegiatanID:         kegiatan.ID,
+		Narasi:             input.Narasi,
+		RingkasanKehadiran: ringkasan,
+		Lampiran:           []model.LampiranLPJ{},
+		RealisasiAnggaran:  input.RealisasiAnggaran,
+		Status:             model.LPJDraft,
+		CreatedBy:          utils.GetUserID(c),
+		CreatedAt:          now,
+		UpdatedBy:          utils.GetUserID(c),
+		UpdatedAt:          now,
+	}
+	// Tanpa input realisasi, isi otomatis dari anggaran kegiatan per kategori
+	if len(lpj.RealisasiAnggaran) == 0 {
+		items, err := findAnggaranItems(ctx, kegiatan.ID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch anggaran"})
+		}
+		lpj.RealisasiAnggaran = []model.RealisasiAnggaran{}
+		for _, varian := range hitungRingkasanAnggaran(items).PerKategori {
+			lpj.RealisasiAnggaran = append(lpj.RealisasiAnggaran, model.RealisasiAnggaran{
+				Uraian:    varian.Kategori,
+				Anggaran:  varian.Rencana,
+				Realisasi: varian.Realisasi,
+			})
+		}
+	}
+	lpj.TotalAnggaran, lpj.TotalRealisasi = hitungTotalAnggaran(lpj.RealisasiAnggaran)
+
+	res, err := config.DB.Collection("lpj").InsertOne(ctx, lpj)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "LPJ already exists for this kegiatan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create LPJ"})
+	}
+	lpj.ID = res.InsertedID.(primitive.ObjectID)
+	return c.Status(201).JSON(lpj)
+}
+
+// UpdateLPJ godoc
+// @Summary Update LPJ narrative and budget realization
+// @Tags LPJ
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param lpj body LPJRequest true "LPJ Data"
+// @Success 200 {object} model.LPJ
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/lpj [put]
+// @Security BearerAuth
+func UpdateLPJ(c *fiber.Ctx) error {
+	var input LPJRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := lpjValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can manage this LPJ"})
+	}
+	lpj, err := findLPJ(ctx, kegiatan.ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "LPJ not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch LPJ"})
+	}
+	if lpj.Status != model.LPJDraft && lpj.Status != model.LPJRevision {
+		return c.Status(409).JSON(fiber.Map{"error": "LPJ can no longer be edited"})
+	}
+
+	lpj.Narasi = input.Narasi
+	lpj.RealisasiAnggaran = input.RealisasiAnggaran
+	if lpj.RealisasiAnggaran == nil {
+		lpj.RealisasiAnggaran = []model.RealisasiAnggaran{}
+	}
+	lpj.TotalAnggaran, lpj.TotalRealisasi = hitungTotalAnggaran(lpj.RealisasiAnggaran)
+	lpj.UpdatedBy = utils.GetUserID(c)
+	lpj.UpdatedAt = time.Now()
+	update := bson.M{
+		"narasi":              lpj.Narasi,
+		"realisasi_anggaran":  lpj.RealisasiAnggaran,
+		"total_anggaran":      lpj.TotalAnggaran,
+		"total_realisasi":     lpj.TotalRealisasi,
+		"ringkasan_kehadiran": lpj.RingkasanKehadiran,
+		"updated_by":          lpj.UpdatedBy,
+		"updated_at":          lpj.UpdatedAt,
+	}
+	_, err = config.DB.Collection("lpj").UpdateOne(ctx, bson.M{"_id": lpj.ID}, bson.M{"$set": update})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update LPJ"})
+	}
+	return c.JSON(lpj)
+}
+
+// UploadLampiranLPJ godoc
+// @Summary Upload an attachment to the LPJ
+// @Tags LPJ
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param file formData file true "File lampiran (pdf, gambar, dokumen office, maks 10MB)"
+// @Param keterangan formData string false "Keterangan lampiran"
+// @Success 201 {object} model.LampiranLPJ
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/lpj/lampiran [post]
+// @Security BearerAuth
+func UploadLampiranLPJ(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can manage this LPJ"})
+	}
+	lpj, err := findLPJ(ctx, kegiatan.ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "LPJ not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch LPJ"})
+	}
+	if lpj.Status != model.LPJDraft && lpj.Status != model.LPJRevision {
+		return c.Status(409).JSON(fiber.Map{"error": "LPJ can no longer be edited"})
+	}
+	url, err := utils.SaveUpload(c, "file", "lpj")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	file, _ := c.FormFile("file")
+	lampiran := model.LampiranLPJ{
+		ID:         primitive.NewObjectID(),
+		NamaFile:   file.Filename,
+		URL:        url,
+		Keterangan: c.FormValue("keterangan"),
+		UploadedBy: utils.GetUserID(c),
+		UploadedAt: time.Now(),
+	}
+	update := bson.M{
+		"$push": bson.M{"lampiran": lampiran},
+		"$set":  bson.M{"updated_by": lampiran.UploadedBy, "updated_at": lampiran.UploadedAt},
+	}
+	_, err = config.DB.Collection("lpj").UpdateOne(ctx, bson.M{"_id": lpj.ID}, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save lampiran"})
+	}
+	return c.Status(201).JSON(lampiran)
+}
+
+// DeleteLampiranLPJ godoc
+// @Summary Remove an attachment from the LPJ
+// @Tags LPJ
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param lampiranId path string true "Lampiran ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/lpj/lampiran/{lampiranId} [delete]
+// @Security BearerAuth
+func DeleteLampiranLPJ(c *fiber.Ctx) error {
+	lampiranID, err := primitive.ObjectIDFromHex(c.Params("lampiranId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid lampiran ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can manage this LPJ"})
+	}
+	filter := bson.M{
+		"kegiatan_id":  kegiatan.ID,
+		"status":       bson.M{"$in": []string{model.LPJDraft, model.LPJRevision}},
+		"lampiran._id": lampiranID,
+	}
+	update := bson.M{
+		"$pull": bson.M{"lampiran": bson.M{"_id": lampiranID}},
+		"$set":  bson.M{"updated_by": utils.GetUserID(c), "updated_at": time.Now()},
+	}
+	res, err := config.DB.Collection("lpj").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete lampiran"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Lampiran not found or LPJ can no longer be edited"})
+	}
+	return c.JSON(fiber.Map{"message": "Lampiran deleted"})
+}
+
+// SubmitLPJ godoc
+// @Summary Submit LPJ for review
+// @Description Ringkasan kehadiran dibekukan saat submit dan reviewer menerima notifikasi
+// @Tags LPJ
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} model.LPJ
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/lpj/submit [post]
+// @Security BearerAuth
+func SubmitLPJ(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can manage this LPJ"})
+	}
+	lpj, err := findLPJ(ctx, kegiatan.ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "LPJ not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch LPJ"})
+	}
+	if lpj.Status != model.LPJDraft && lpj.Status != model.LPJRevision {
+		return c.Status(409).JSON(fiber.Map{"error": "Only draft or revision LPJ can be submitted"})
+	}
+	if !kegiatanSelesai(kegiatan, time.Now()) {
+		return c.Status(409).JSON(fiber.Map{"error": "LPJ can only be submitted after the kegiatan has ended"})
+	}
+
+	now := time.Now()
+	previousStatus := lpj.Status
+	lpj.Status = model.LPJSubmitted
+	lpj.SubmittedAt = &now
+	lpj.UpdatedBy = utils.GetUserID(c)
+	lpj.UpdatedAt = now
+	update := bson.M{"$set": bson.M{
+		"status":              lpj.Status,
+		"submitted_at":        lpj.SubmittedAt,
+		"ringkasan_kehadiran": lpj.RingkasanKehadiran,
+		"updated_by":          lpj.UpdatedBy,
+		"updated_at":          lpj.UpdatedAt,
+	}}
+	res, err := config.DB.Collection("lpj").UpdateOne(ctx, bson.M{"_id": lpj.ID, "status": previousStatus}, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to submit LPJ"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "LPJ status has changed, please reload"})
+	}
+
+	reviewers, err := findUserIDs(ctx, bson.M{"role": "reviewer"})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch reviewers"})
+	}
+	notify(ctx, reviewers, "lpj_submitted", "LPJ baru",
+		fmt.Sprintf("LPJ kegiatan \"%s\" (%s) menunggu review", kegiatan.Judul, kegiatan.Kategori), kegiatan.ID)
+
+	return c.JSON(lpj)
+}
+
+// ReviewLPJ godoc
+// @Summary Approve an LPJ or request revision
+// @Tags LPJ
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param review body ReviewLPJRequest true "Keputusan reviewer"
+// @Success 200 {object} model.LPJ
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/lpj/review [post]
+// @Security BearerAuth
+func ReviewLPJ(c *fiber.Ctx) error {
+	var input ReviewLPJRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := lpjValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	lpj, err := findLPJ(ctx, kegiatan.ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "LPJ not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch LPJ"})
+	}
+	if lpj.Status != model.LPJSubmitted {
+		return c.Status(409).JSON(fiber.Map{"error": "Only submitted LPJ can be reviewed"})
+	}
+
+	now := time.Now()
+	review := model.LPJReview{
+		ReviewerID: utils.GetUserID(c),
+		Status:     input.Status,
+		Komentar:   input.Komentar,
+		CreatedAt:  now,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     input.Status,
+			"updated_by": review.ReviewerID,
+			"updated_at": now,
+		},
+		"$push": bson.M{"reviews": review},
+	}
+	res, err := config.DB.Collection("lpj").UpdateOne(ctx, bson.M{"_id": lpj.ID, "status": model.LPJSubmitted}, update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to review LPJ"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "LPJ status has changed, please reload"})
+	}
+	lpj.Status = input.Status
+	lpj.Reviews = append(lpj.Reviews, review)
+	lpj.UpdatedBy = review.ReviewerID
+	lpj.UpdatedAt = now
+
+	judul := "LPJ disetujui"
+	pesan := fmt.Sprintf("LPJ kegiatan \"%s\" telah disetujui", kegiatan.Judul)
+	if input.Status == model.LPJRevision {
+		judul = "LPJ perlu revisi"
+		pesan = fmt.Sprintf("LPJ kegiatan \"%s\" perlu direvisi: %s", kegiatan.Judul, input.Komentar)
+	}
+	if creatorID, err := primitive.ObjectIDFromHex(lpj.CreatedBy); err == nil {
+		notify(ctx, []primitive.ObjectID{creatorID}, "lpj_"+input.Status, judul, pesan, kegiatan.ID)
+	}
+
+	return c.JSON(lpj)
+}
+
+// GetOverdueLPJ godoc
+// @Summary List kegiatan with overdue LPJ
+// @Description Kegiatan approved yang sudah melewati batas LPJ_DEADLINE_DAYS tetapi LPJ-nya belum di-submit atau disetujui.
+// @Description Admin hanya melihat kegiatan yang diselenggarakan UKM-nya.
+// @Tags LPJ
+// @Produce json
+// @Success 200 {array} OverdueLPJ
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /lpj/overdue [get]
+// @Security BearerAuth
+func GetOverdueLPJ(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	// Reviewer melihat semua UKM, admin hanya kegiatan yang diselenggarakan UKM-nya
+	filter := bson.M{}
+	if utils.GetUserRole(c) != "reviewer" {
+		dikelola, ok, err := filterKegiatanDikelola(ctx, c)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+		}
+		if !ok {
+			return c.Status(403).JSON(fiber.Map{"error": "Only reviewers and UKM admins can view overdue LPJ"})
+		}
+		filter = dikelola
+	}
+	cursor, err := config.DB.Collection("kegiatan").Find(ctx, approvedOnly(notDeleted(filter)))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	var kegiatans []model.Kegiatan
+	if err := cursor.All(ctx, &kegiatans); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode kegiatan"})
+	}
+
+	// Tanggal kegiatan disimpan sebagai string sehingga batas waktu dihitung di aplikasi
+	now := time.Now()
+	deadlines := map[primitive.ObjectID]time.Time{}
+	ids := []primitive.ObjectID{}
+	for _, k := range kegiatans {
+		tanggal, err := utils.ParseTanggal(k.Tanggal)
+		if err != nil {
+			continue
+		}
+		if batas := tanggal.Add(lpjDeadline()); batas.Before(now) {
+			deadlines[k.ID] = batas
+			ids = append(ids, k.ID)
+		}
+	}
+
+	statusLPJ := map[primitive.ObjectID]string{}
+	if len(ids) > 0 {
+		cursor, err = config.DB.Collection("lpj").Find(ctx, bson.M{"kegiatan_id": bson.M{"$in": ids}})
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch LPJ"})
+		}
+		var lpjs []model.LPJ
+		if err := cursor.All(ctx, &lpjs); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to decode LPJ"})
+		}
+		for _, l := range lpjs {
+			statusLPJ[l.KegiatanID] = l.Status
+		}
+	}
+
+	overdue := []OverdueLPJ{}
+	for _, k := range kegiatans {
+		batas, ok := deadlines[k.ID]
+		if !ok {
+			continue
+		}
+		status, exists := statusLPJ[k.ID]
+		if status == model.LPJSubmitted || status == model.LPJApproved {
+			continue
+		}
+		if !exists {
+			status = "belum dibuat"
+		}
+		overdue = append(overdue, OverdueLPJ{
+			KegiatanID:    k.ID.Hex(),
+			Judul:         k.Judul,
+			Kategori:      k.Kategori,
+			Tanggal:       k.Tanggal,
+			BatasLPJ:      batas.Format("2006-01-02"),
+			HariTerlambat: int(math.Floor(now.Sub(batas).Hours() / 24)),
+			StatusLPJ:     status,
+		})
+	}
+	return c.JSON(overdue)
+}
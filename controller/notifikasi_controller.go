@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// notify menyimpan notifikasi untuk setiap user tujuan. Kegagalan hanya dicatat di log
+// supaya tidak menggagalkan proses utama yang memicu notifikasi.
+func notify(ctx context.Context, userIDs []primitive.ObjectID, tipe, judul, pesan string, refID primitive.ObjectID) {
+	seen := make(map[primitive.ObjectID]bool)
+	docs := make([]interface{}, 0, len(userIDs))
+	now := time.Now()
+	for _, userID := range userIDs {
+		if userID.IsZero() || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		docs = append(docs, model.Notifikasi{
+			UserID:    userID,
+			Judul:     judul,
+			Pesan:     pesan,
+			Tipe:      tipe,
+			RefID:     refID,
+			CreatedAt: now,
+		})
+	}
+	if len(docs) == 0 {
+		return
+	}
+	if _, err := config.DB.Collection("notifikasi").InsertMany(ctx, docs); err != nil {
+		log.Println("Gagal menyimpan notifikasi:", err)
+	}
+}
+
+// GetNotifikasi godoc
+// @Summary Get notifications of the logged in user
+// @Tags Notifikasi
+// @Produce json
+// @Param unread query bool false "Hanya notifikasi yang belum dibaca"
+// @Success 200 {array} model.Notifikasi
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /notifikasi [get]
+// @Security BearerAuth
+func GetNotifikasi(c *fiber.Ctx) error {
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid token"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filter := bson.M{"user_id": userID}
+	if c.QueryBool("unread") {
+		filter["dibaca"] = false
+	}
+	opts := options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(100)
+	cursor, err := config.DB.Collection("notifikasi").Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch notifikasi"})
+	}
+	notifikasis := []model.Notifikasi{}
+	if err := cursor.All(ctx, &notifikasis); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode notifikasi"})
+	}
+	return c.JSON(notifikasis)
+}
+
+// ReadNotifikasi godoc
+// @Summary Mark a notification as read
+// @Tags Notifikasi
+// @Produce json
+// @Param id path string true "Notifikasi ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /notifikasi/{id}/read [put]
+// @Security BearerAuth
+func ReadNotifikasi(c *fiber.Ctx) error {
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid token"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	res, err := config.DB.Collection("notifikasi").UpdateOne(ctx, bson.M{"_id": objID, "user_id": userID}, bson.M{"$set": bson.M{"dibaca": true}})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update notifikasi"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Notifikasi not found"})
+	}
+	return c.JSON(fiber.Map{"message": "Notifikasi marked as read"})
+}
+
+// ReadAllNotifikasi godoc
+// @Summary Mark all notifications as read
+// @Tags Notifikasi
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /notifikasi/read-all [put]
+// @Security BearerAuth
+func ReadAllNotifikasi(c *fiber.Ctx) error {
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid token"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	res, err := config.DB.Collection("notifikasi").UpdateMany(ctx, bson.M{"user_id": userID, "dibaca": false}, bson.M{"$set": bson.M{"dibaca": true}})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update notifikasi"})
+	}
+	return c.JSON(fiber.Map{"message": "Notifikasi marked as read", "updated": res.ModifiedCount})
+}
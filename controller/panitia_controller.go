@@ -0,0 +1,305 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var panitiaValidate = validator.New()
+
+type UpdatePanitiaRequest struct {
+	Peran  string `json:"peran" validate:"required,oneof=ketua_pelaksana sekretaris bendahara divisi"`
+	Divisi string `json:"divisi" validate:"required_if=Peran divisi"`
+}
+
+// canManagePanitia mengizinkan admin UKM penyelenggara (termasuk co-host) dan ketua pelaksana kegiatan
+func canManagePanitia(ctx context.Context, c *fiber.Ctx, kegiatan model.Kegiatan) (bool, error) {
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil || allowed {
+		return allowed, err
+	}
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return false, nil
+	}
+	count, err := config.DB.Collection("panitia").CountDocuments(ctx, bson.M{
+		"kegiatan_id": kegiatan.ID,
+		"user_id":     userID,
+		"peran":       model.PeranKetuaPelaksana,
+	})
+	return count > 0, err
+}
+
+// GetPanitia godoc
+// @Summary Get committee of a kegiatan
+// @Description user_email hanya ditampilkan untuk admin UKM penyelenggara dan ketua pelaksana
+// @Tags Panitia
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {array} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/panitia [get]
+// @Security BearerAuth
+func GetPanitia(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	pengelola, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	// Member hanya melihat kegiatan yang sudah approved, sama seperti GetKegiatanByID
+	if !pengelola && utils.GetUserRole(c) == "member" && approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+	}
+	project := bson.M{
+		"_id":         1,
+		"kegiatan_id": 1,
+		"user_id":     1,
+		"peran":       1,
+		"divisi":      1,
+		"user_nama":   bson.M{"$arrayElemAt": []interface{}{"$user_data.nama", 0}},
+	}
+	// Email panitia hanya untuk pengelola panitia
+	if pengelola {
+		project["user_email"] = bson.M{"$arrayElemAt": []interface{}{"$user_data.email", 0}}
+	}
+	pipeline := []bson.M{
+		{"$match": bson.M{"kegiatan_id": kegiatan.ID}},
+		{
+			"$lookup": bson.M{
+				"from":         "users",
+				"localField":   "user_id",
+				"foreignField": "_id",
+				"as":           "user_data",
+			},
+		},
+		{"$project": project},
+		{"$sort": bson.M{"peran": 1}},
+	}
+	cursor, err := config.DB.Collection("panitia").Aggregate(ctx, pipeline)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch panitia"})
+	}
+	panitia := []bson.M{}
+	if err := cursor.All(ctx, &panitia); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode panitia"})
+	}
+	return c.JSON(panitia)
+}
+
+// AddPanitia godoc
+// @Summary Add a committee member to a kegiatan
+// @Description Peran: ketua_pelaksana (hanya satu per kegiatan), sekretaris, bendahara, divisi (wajib isi divisi)
+// @Tags Panitia
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param panitia body model.Panitia true "Anggota panitia"
+// @Success 201 {object} model.Panitia
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/panitia [post]
+// @Security BearerAuth
+func AddPanitia(c *fiber.Ctx) error {
+	var panitia model.Panitia
+	if err := c.BodyParser(&panitia); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := panitiaValidate.Struct(panitia); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only UKM admins or ketua pelaksana can manage panitia"})
+	}
+
+	count, err := config.DB.Collection("users").CountDocuments(ctx, notDeleted(bson.M{"_id": panitia.UserID}))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate user"})
+	}
+	if count == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "User not found"})
+	}
+	if panitia.Peran == model.PeranKetuaPelaksana {
+		count, err := config.DB.Collection("panitia").CountDocuments(ctx, bson.M{"kegiatan_id": kegiatan.ID, "peran": model.PeranKetuaPelaksana})
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to validate peran"})
+		}
+		if count > 0 {
+			return c.Status(409).JSON(fiber.Map{"error": "Kegiatan already has a ketua pelaksana"})
+		}
+	}
+
+	now := time.Now()
+	panitia.ID = primitive.NilObjectID
+	panitia.KegiatanID = kegiatan.ID
+	panitia.CreatedBy = utils.GetUserID(c)
+	panitia.CreatedAt = now
+	panitia.UpdatedBy = panitia.CreatedBy
+	panitia.UpdatedAt = now
+	res, err := config.DB.Collection("panitia").InsertOne(ctx, panitia)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "User is already in the panitia"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to add panitia"})
+	}
+	panitia.ID = res.InsertedID.(primitive.ObjectID)
+
+	notify(ctx, []primitive.ObjectID{panitia.UserID}, "panitia_added", "Anda ditunjuk sebagai panitia",
+		fmt.Sprintf("Anda menjadi panitia (%s) kegiatan \"%s\"", panitia.Peran, kegiatan.Judul), kegiatan.ID)
+
+	return c.Status(201).JSON(panitia)
+}
+
+// UpdatePanitia godoc
+// @Summary Change the role of a committee member
+// @Tags Panitia
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param panitiaId path string true "Panitia ID"
+// @Param panitia body UpdatePanitiaRequest true "Peran baru"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/panitia/{panitiaId} [put]
+// @Security BearerAuth
+func UpdatePanitia(c *fiber.Ctx) error {
+	panitiaID, err := primitive.ObjectIDFromHex(c.Params("panitiaId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid panitia ID"})
+	}
+	var input UpdatePanitiaRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := panitiaValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only UKM admins or ketua pelaksana can manage panitia"})
+	}
+	if input.Peran == model.PeranKetuaPelaksana {
+		count, err := config.DB.Collection("panitia").CountDocuments(ctx, bson.M{
+			"kegiatan_id": kegiatan.ID,
+			"peran":       model.PeranKetuaPelaksana,
+			"_id":         bson.M{"$ne": panitiaID},
+		})
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to validate peran"})
+		}
+		if count > 0 {
+			return c.Status(409).JSON(fiber.Map{"error": "Kegiatan already has a ketua pelaksana"})
+		}
+	}
+	if input.Peran != model.PeranDivisi {
+		input.Divisi = ""
+	}
+	update := bson.M{
+		"peran":      input.Peran,
+		"divisi":     input.Divisi,
+		"updated_by": utils.GetUserID(c),
+		"updated_at": time.Now(),
+	}
+	res, err := config.DB.Collection("panitia").UpdateOne(ctx, bson.M{"_id": panitiaID, "kegiatan_id": kegiatan.ID}, bson.M{"$set": update})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update panitia"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Panitia not found"})
+	}
+	return c.JSON(fiber.Map{"message": "Panitia updated"})
+}
+
+// RemovePanitia godoc
+// @Summary Remove a committee member
+// @Description Tugas yang masih ditugaskan ke anggota tersebut menjadi tanpa penanggung jawab
+// @Tags Panitia
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param panitiaId path string true "Panitia ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/panitia/{panitiaId} [delete]
+// @Security BearerAuth
+func RemovePanitia(c *fiber.Ctx) error {
+	panitiaID, err := primitive.ObjectIDFromHex(c.Params("panitiaId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid panitia ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only UKM admins or ketua pelaksana can manage panitia"})
+	}
+	var panitia model.Panitia
+	err = config.DB.Collection("panitia").FindOneAndDelete(ctx, bson.M{"_id": panitiaID, "kegiatan_id": kegiatan.ID}).Decode(&panitia)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Panitia not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to remove panitia"})
+	}
+	_, err = config.DB.Collection("tugas").UpdateMany(ctx,
+		bson.M{"kegiatan_id": kegiatan.ID, "assignee_id": panitia.UserID},
+		bson.M{"$unset": bson.M{"assignee_id": ""}, "$set": bson.M{"updated_by": utils.GetUserID(c), "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to unassign tugas"})
+	}
+	return c.JSON(fiber.Map{"message": "Panitia removed"})
+}
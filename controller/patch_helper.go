@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"backend-sisteminformasi/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Field yang dikelola server dan tidak boleh diubah lewat PATCH
+var patchReadOnlyFields = []string{"id", "created_by", "created_at", "updated_by", "updated_at", "deleted_at", "deleted_by"}
+
+// applyMergePatch menggabungkan body JSON Merge Patch ke doc (pointer ke struct model
+// yang sudah diambil dari database). doc berisi hasil gabungan untuk divalidasi ulang,
+// sedangkan map yang dikembalikan hanya berisi field bson yang disebut di patch.
+func applyMergePatch(body []byte, doc interface{}, readOnly ...string) (bson.M, error) {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, errors.New("Invalid request body")
+	}
+	for _, field := range append(patchReadOnlyFields, readOnly...) {
+		delete(patch, field)
+	}
+	if len(patch) == 0 {
+		return nil, errors.New("No fields to update")
+	}
+
+	original, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	cleanPatch, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := utils.MergePatch(original, cleanPatch)
+	if err != nil {
+		return nil, err
+	}
+
+	value := reflect.ValueOf(doc).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	if err := json.Unmarshal(merged, doc); err != nil {
+		return nil, errors.New("Invalid field value")
+	}
+
+	update := bson.M{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		jsonName := tagName(field.Tag.Get("json"))
+		if _, ok := patch[jsonName]; !ok {
+			continue
+		}
+		bsonName := tagName(field.Tag.Get("bson"))
+		if bsonName == "" || bsonName == "-" {
+			continue
+		}
+		update[bsonName] = value.Field(i).Interface()
+	}
+	if len(update) == 0 {
+		return nil, errors.New("No fields to update")
+	}
+	return update, nil
+}
+
+func tagName(tag string) string {
+	return strings.Split(tag, ",")[0]
+}
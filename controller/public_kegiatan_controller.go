@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Respons publik boleh di-cache browser/CDN selama satu menit
+const publicCacheControl = "public, max-age=60"
+
+const (
+	defaultPublicLimit = 50
+	maxPublicLimit     = 100
+)
+
+// PublicKegiatanResponse hanya memuat field yang aman ditampilkan tanpa login
+type PublicKegiatanResponse struct {
+	ID              string   `json:"id" bson:"_id"`
+	Judul           string   `json:"judul" bson:"judul"`
+	Deskripsi       string   `json:"deskripsi" bson:"deskripsi"`
+	Tanggal         string   `json:"tanggal" bson:"tanggal"`
+	Lokasi          string   `json:"lokasi" bson:"lokasi"`
+	UKM             string   `json:"ukm" bson:"kategori"`
+	CoHosts         []string `json:"co_hosts,omitempty" bson:"co_hosts,omitempty"`
+	MaxParticipants int      `json:"maxParticipants" bson:"maxParticipants"`
+	JumlahTamu      int      `json:"-" bson:"jumlah_tamu"`
+	// SisaKuota kosong jika kegiatan tidak membatasi peserta
+	SisaKuota *int `json:"sisa_kuota,omitempty" bson:"-"`
+}
+
+// publicKegiatanFilter hanya meloloskan kegiatan publik yang sudah disetujui dan belum dihapus
+func publicKegiatanFilter(filter bson.M) bson.M {
+	filter["publik"] = true
+	return approvedOnly(notDeleted(filter))
+}
+
+// findPublicKegiatan menjalankan pipeline dengan proyeksi field publik dan jumlah tamu terdaftar
+func findPublicKegiatan(ctx context.Context, filter bson.M, limit int) ([]PublicKegiatanResponse, error) {
+	pipeline := []bson.M{
+		{"$match": filter},
+		{"$sort": bson.M{"tanggal": 1}},
+		{"$limit": limit},
+		{
+			"$lookup": bson.M{
+				"from": "tamu",
+				"let":  bson.M{"kegiatan_id": "$_id"},
+				"pipeline": []bson.M{
+					{"$match": bson.M{"$expr": bson.M{"$eq": []interface{}{"$kegiatan_id", "$$kegiatan_id"}}}},
+					{"$count": "total"},
+				},
+				"as": "tamu_count",
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":             bson.M{"$toString": "$_id"},
+				"judul":           1,
+				"deskripsi":       1,
+				"tanggal":         1,
+				"lokasi":          1,
+				"kategori":        1,
+				"co_hosts":        1,
+				"maxParticipants": 1,
+				"jumlah_tamu": bson.M{
+					"$ifNull": []interface{}{
+						bson.M{"$arrayElemAt": []interface{}{"$tamu_count.total", 0}},
+						0,
+					},
+				},
+			},
+		},
+	}
+	cursor, err := config.DB.Collection("kegiatan").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	results := []PublicKegiatanResponse{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	for i := range results {
+		if results[i].MaxParticipants > 0 {
+			sisa := results[i].MaxParticipants - results[i].JumlahTamu
+			if sisa < 0 {
+				sisa = 0
+			}
+			results[i].SisaKuota = &sisa
+		}
+	}
+	return results, nil
+}
+
+// GetPublicKegiatan godoc
+// @Summary Get public upcoming kegiatan
+// @Description Daftar kegiatan publik yang sudah disetujui untuk halaman depan kampus, tanpa login. Secara default hanya kegiatan mulai hari ini.
+// @Tags Public
+// @Produce json
+// @Param ukm query string false "Filter UKM penyelenggara (termasuk co-host)"
+// @Param semua query bool false "Sertakan kegiatan yang sudah lewat"
+// @Param limit query int false "Jumlah maksimal data (default 50, maks 100)"
+// @Success 200 {array} PublicKegiatanResponse
+// @Failure 429 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /public/kegiatan [get]
+func GetPublicKegiatan(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filter := bson.M{}
+	if !c.QueryBool("semua") {
+		// Tanggal disimpan sebagai string ISO sehingga bisa dibandingkan langsung
+		filter["tanggal"] = bson.M{"$gte": time.Now().In(utils.Location).Format("2006-01-02")}
+	}
+	if ukm := c.Query("ukm"); ukm != "" {
+		filter["$or"] = []bson.M{{"kategori": ukm}, {"co_hosts": ukm}}
+	}
+	limit := c.QueryInt("limit", defaultPublicLimit)
+	if limit <= 0 || limit > maxPublicLimit {
+		limit = maxPublicLimit
+	}
+	results, err := findPublicKegiatan(ctx, publicKegiatanFilter(filter), limit)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	c.Set(fiber.HeaderCacheControl, publicCacheControl)
+	return c.JSON(results)
+}
+
+// GetPublicKegiatanByID godoc
+// @Summary Get public kegiatan by ID
+// @Description Kegiatan internal atau yang belum disetujui dianggap tidak ada
+// @Tags Public
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} PublicKegiatanResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /public/kegiatan/{id} [get]
+func GetPublicKegiatanByID(c *fiber.Ctx) error {
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results, err := findPublicKegiatan(ctx, publicKegiatanFilter(bson.M{"_id": objID}), 1)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	if len(results) == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+	}
+	c.Set(fiber.HeaderCacheControl, publicCacheControl)
+	return c.JSON(results[0])
+}
@@ -0,0 +1,512 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var sertifikatValidate = validator.New()
+
+var errTidakHadir = errors.New("user did not attend this kegiatan")
+
+var errKegiatanBelumApproved = errors.New("kegiatan is not approved")
+
+type TemplateSertifikatRequest struct {
+	Judul         string                `json:"judul" validate:"required"`
+	Keterangan    string                `json:"keterangan" validate:"required"`
+	Penandatangan []model.Penandatangan `json:"penandatangan" validate:"required,min=1,max=3,dive"`
+}
+
+type GenerateSertifikatResponse struct {
+	Diterbitkan int `json:"diterbitkan"`
+	SudahAda    int `json:"sudah_ada"`
+	Gagal       int `json:"gagal"`
+}
+
+type VerifikasiSertifikatResponse struct {
+	Valid         bool      `json:"valid"`
+	Serial        string    `json:"serial"`
+	NamaPeserta   string    `json:"nama_peserta"`
+	JudulKegiatan string    `json:"judul_kegiatan"`
+	Tanggal       string    `json:"tanggal"`
+	UKM           string    `json:"ukm"`
+	IssuedAt      time.Time `json:"issued_at"`
+}
+
+// newSerialSertifikat membuat nomor seri acak, contoh SRT-2025-9F3A01C2B4
+func newSerialSertifikat() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "SRT-" + time.Now().In(utils.Location).Format("2006") + "-" + strings.ToUpper(hex.EncodeToString(b)), nil
+}
+
+// verifyURL adalah link publik yang dimuat di QR code sertifikat
+func verifyURL(c *fiber.Ctx, serial string) string {
+	base := os.Getenv("APP_BASE_URL")
+	if base == "" {
+		base = c.BaseURL()
+	}
+	return strings.TrimRight(base, "/") + "/certificates/" + serial + "/verify"
+}
+
+// formatTanggalSertifikat mengubah tanggal kegiatan menjadi "2 Januari 2006" jika formatnya dikenali
+func formatTanggalSertifikat(tanggal string) string {
+	t, err := utils.ParseTanggal(tanggal)
+	if err != nil {
+		return tanggal
+	}
+	return utils.FormatTanggalIndonesia(t)
+}
+
+func findTemplateSertifikat(ctx context.Context, kegiatanID primitive.ObjectID) (model.TemplateSertifikat, error) {
+	var template model.TemplateSertifikat
+	err := config.DB.Collection("template_sertifikat").FindOne(ctx, bson.M{"kegiatan_id": kegiatanID}).Decode(&template)
+	return template, err
+}
+
+// sertifikatBerlaku mengecek ulang dasar penerbitan sertifikat: kegiatan masih ada dan
+// approved, serta kehadiran peserta masih tercatat hadir. Jika tidak, sertifikat dianggap dicabut.
+func sertifikatBerlaku(ctx context.Context, sertifikat model.Sertifikat) (bool, error) {
+	count, err := config.DB.Collection("kegiatan").CountDocuments(ctx, approvedOnly(notDeleted(bson.M{"_id": sertifikat.KegiatanID})))
+	if err != nil || count == 0 {
+		return false, err
+	}
+	count, err = config.DB.Collection("kehadiran").CountDocuments(ctx, notDeleted(filterHadir(bson.M{
+		"user_id":     sertifikat.UserID,
+		"kegiatan_id": sertifikat.KegiatanID,
+	})))
+	return count > 0, err
+}
+
+// issueSertifikat menerbitkan sertifikat untuk user yang hadir. Jika sudah pernah
+// diterbitkan, sertifikat lama dikembalikan dengan created=false.
+func issueSertifikat(ctx context.Context, kegiatan model.Kegiatan, userID primitive.ObjectID, issuedBy string) (model.Sertifikat, bool, error) {
+	var sertifikat model.Sertifikat
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return sertifikat, false, errKegiatanBelumApproved
+	}
+	filter := bson.M{"kegiatan_id": kegiatan.ID, "user_id": userID}
+	err := config.DB.Collection("sertifikat").FindOne(ctx, filter).Decode(&sertifikat)
+	if err == nil {
+		return sertifikat, false, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return sertifikat, false, err
+	}
+
+	count, err := config.DB.Collection("kehadiran").CountDocuments(ctx, notDeleted(filterHadir(bson.M{
+		"user_id":     userID,
+		"kegiatan_id": kegiatan.ID,
+	})))
+	if err != nil {
+		return sertifikat, false, err
+	}
+	if count == 0 {
+		return sertifikat, false, errTidakHadir
+	}
+	var user model.User
+	if err := config.DB.Collection("users").FindOne(ctx, notDeleted(bson.M{"_id": userID})).Decode(&user); err != nil {
+		return sertifikat, false, err
+	}
+
+	sertifikat = model.Sertifikat{
+		KegiatanID:    kegiatan.ID,
+		UserID:        userID,
+		NamaPeserta:   user.Nama,
+		JudulKegiatan: kegiatan.Judul,
+		Tanggal:       kegiatan.Tanggal,
+		UKM:           kegiatan.Kategori,
+		IssuedBy:      issuedBy,
+		IssuedAt:      time.Now(),
+	}
+	// Coba ulang jika nomor seri bentrok
+	for attempt := 0; attempt < 3; attempt++ {
+		serial, err := newSerialSertifikat()
+		if err != nil {
+			return sertifikat, false, err
+		}
+		sertifikat.Serial = serial
+		res, err := config.DB.Collection("sertifikat").InsertOne(ctx, sertifikat)
+		if err == nil {
+			sertifikat.ID = res.InsertedID.(primitive.ObjectID)
+			return sertifikat, true, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return sertifikat, false, err
+		}
+		// Sertifikat untuk user ini mungkin baru diterbitkan oleh request lain
+		if err := config.DB.Collection("sertifikat").FindOne(ctx, filter).Decode(&sertifikat); err == nil {
+			return sertifikat, false, nil
+		}
+	}
+	return sertifikat, false, errors.New("failed to generate unique serial")
+}
+
+// GetTemplateSertifikat godoc
+// @Summary Get certificate template of a kegiatan
+// @Tags Sertifikat
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} model.TemplateSertifikat
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/sertifikat/template [get]
+// @Security BearerAuth
+func GetTemplateSertifikat(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	template, err := findTemplateSertifikat(ctx, kegiatan.ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Template sertifikat not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch template sertifikat"})
+	}
+	return c.JSON(template)
+}
+
+// SaveTemplateSertifikat godoc
+// @Summary Create or update certificate template of a kegiatan
+// @Description Judul (mis. "Sertifikat Partisipasi"), keterangan sebelum nama kegiatan, dan 1-3 penandatangan
+// @Tags Sertifikat
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param template body TemplateSertifikatRequest true "Template sertifikat"
+// @Success 200 {object} model.TemplateSertifikat
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/sertifikat/template [put]
+// @Security BearerAuth
+func SaveTemplateSertifikat(c *fiber.Ctx) error {
+	var input TemplateSertifikatRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := sertifikatValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can manage sertifikat"})
+	}
+
+	userID := utils.GetUserID(c)
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"judul":         input.Judul,
+			"keterangan":    input.Keterangan,
+			"penandatangan": input.Penandatangan,
+			"updated_by":    userID,
+			"updated_at":    now,
+		},
+		"$setOnInsert": bson.M{
+			"kegiatan_id": kegiatan.ID,
+			"created_by":  userID,
+			"created_at":  now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var template model.TemplateSertifikat
+	err = config.DB.Collection("template_sertifikat").FindOneAndUpdate(ctx, bson.M{"kegiatan_id": kegiatan.ID}, update, opts).Decode(&template)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save template sertifikat"})
+	}
+	return c.JSON(template)
+}
+
+// GenerateSertifikat godoc
+// @Summary Issue certificates for all attendees of a kegiatan
+// @Description Menerbitkan sertifikat untuk setiap kehadiran berstatus hadir. Sertifikat yang sudah ada tidak diterbitkan ulang.
+// @Tags Sertifikat
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} GenerateSertifikatResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/sertifikat/generate [post]
+// @Security BearerAuth
+func GenerateSertifikat(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can manage sertifikat"})
+	}
+	if approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(409).JSON(fiber.Map{"error": "Sertifikat can only be issued for approved kegiatan"})
+	}
+	if _, err := findTemplateSertifikat(ctx, kegiatan.ID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(400).JSON(fiber.Map{"error": "Template sertifikat has not been set"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch template sertifikat"})
+	}
+
+	userIDs, err := config.DB.Collection("kehadiran").Distinct(ctx, "user_id", notDeleted(filterHadir(bson.M{
+		"kegiatan_id": kegiatan.ID,
+	})))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kehadiran"})
+	}
+	var result GenerateSertifikatResponse
+	issuedBy := utils.GetUserID(c)
+	for _, raw := range userIDs {
+		userID, ok := raw.(primitive.ObjectID)
+		if !ok {
+			result.Gagal++
+			continue
+		}
+		_, created, err := issueSertifikat(ctx, kegiatan, userID, issuedBy)
+		switch {
+		case err != nil:
+			result.Gagal++
+		case created:
+			result.Diterbitkan++
+		default:
+			result.SudahAda++
+		}
+	}
+	return c.JSON(result)
+}
+
+// GetSertifikatKegiatan godoc
+// @Summary Get issued certificates of a kegiatan
+// @Tags Sertifikat
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {array} model.Sertifikat
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/sertifikat [get]
+// @Security BearerAuth
+func GetSertifikatKegiatan(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	opts := options.Find().SetSort(bson.M{"nama_peserta": 1})
+	cursor, err := config.DB.Collection("sertifikat").Find(ctx, bson.M{"kegiatan_id": kegiatan.ID}, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch sertifikat"})
+	}
+	sertifikat := []model.Sertifikat{}
+	if err := cursor.All(ctx, &sertifikat); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode sertifikat"})
+	}
+	return c.JSON(sertifikat)
+}
+
+// ClaimSertifikat godoc
+// @Summary Issue the certificate of the logged-in user for a kegiatan
+// @Description Anggota yang tercatat hadir dapat menerbitkan sertifikatnya sendiri setelah template diatur
+// @Tags Sertifikat
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} model.Sertifikat
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/sertifikat/me [post]
+// @Security BearerAuth
+func ClaimSertifikat(c *fiber.Ctx) error {
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	if _, err := findTemplateSertifikat(ctx, kegiatan.ID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Sertifikat is not available for this kegiatan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch template sertifikat"})
+	}
+	sertifikat, _, err := issueSertifikat(ctx, kegiatan, userID, userID.Hex())
+	if err != nil {
+		if err == errTidakHadir {
+			return c.Status(403).JSON(fiber.Map{"error": "Only members who attended this kegiatan can get a sertifikat"})
+		}
+		if err == errKegiatanBelumApproved {
+			return c.Status(409).JSON(fiber.Map{"error": "Sertifikat can only be issued for approved kegiatan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to issue sertifikat"})
+	}
+	return c.JSON(sertifikat)
+}
+
+// GetMySertifikat godoc
+// @Summary Get certificates of the logged-in user
+// @Tags Sertifikat
+// @Produce json
+// @Success 200 {array} model.Sertifikat
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /me/sertifikat [get]
+// @Security BearerAuth
+func GetMySertifikat(c *fiber.Ctx) error {
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	opts := options.Find().SetSort(bson.M{"issued_at": -1})
+	cursor, err := config.DB.Collection("sertifikat").Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch sertifikat"})
+	}
+	sertifikat := []model.Sertifikat{}
+	if err := cursor.All(ctx, &sertifikat); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode sertifikat"})
+	}
+	return c.JSON(sertifikat)
+}
+
+// DownloadSertifikat godoc
+// @Summary Download certificate as PDF
+// @Description Hanya pemilik sertifikat, admin, atau reviewer yang dapat mengunduh
+// @Tags Sertifikat
+// @Produce application/pdf
+// @Param serial path string true "Nomor seri sertifikat"
+// @Success 200 {file} file
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /sertifikat/{serial}/pdf [get]
+// @Security BearerAuth
+func DownloadSertifikat(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var sertifikat model.Sertifikat
+	err := config.DB.Collection("sertifikat").FindOne(ctx, bson.M{"serial": c.Params("serial")}).Decode(&sertifikat)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Sertifikat not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch sertifikat"})
+	}
+	role := utils.GetUserRole(c)
+	if sertifikat.UserID.Hex() != utils.GetUserID(c) && role != "admin" && role != "reviewer" {
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden"})
+	}
+	template, err := findTemplateSertifikat(ctx, sertifikat.KegiatanID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Template sertifikat not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch template sertifikat"})
+	}
+
+	data := utils.DataSertifikat{
+		Serial:        sertifikat.Serial,
+		Judul:         template.Judul,
+		NamaPeserta:   sertifikat.NamaPeserta,
+		Keterangan:    template.Keterangan,
+		JudulKegiatan: sertifikat.JudulKegiatan,
+		UKM:           sertifikat.UKM,
+		Tanggal:       formatTanggalSertifikat(sertifikat.Tanggal),
+		VerifyURL:     verifyURL(c, sertifikat.Serial),
+	}
+	for _, p := range template.Penandatangan {
+		data.Penandatangan = append(data.Penandatangan, utils.PenandatanganSertifikat{Nama: p.Nama, Jabatan: p.Jabatan})
+	}
+	pdf, err := utils.RenderSertifikatPDF(data)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to generate sertifikat"})
+	}
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+sertifikat.Serial+`.pdf"`)
+	return c.Send(pdf)
+}
+
+// VerifySertifikat godoc
+// @Summary Verify certificate authenticity
+// @Description Endpoint publik yang dituju QR code pada sertifikat.
+// @Description valid=false jika kegiatan sudah dihapus atau tidak lagi approved, atau kehadiran peserta sudah dihapus atau diubah menjadi tidak hadir.
+// @Tags Sertifikat
+// @Produce json
+// @Param serial path string true "Nomor seri sertifikat"
+// @Success 200 {object} VerifikasiSertifikatResponse
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /certificates/{serial}/verify [get]
+func VerifySertifikat(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var sertifikat model.Sertifikat
+	err := config.DB.Collection("sertifikat").FindOne(ctx, bson.M{"serial": c.Params("serial")}).Decode(&sertifikat)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"valid": false, "error": "Sertifikat not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to verify sertifikat"})
+	}
+	valid, err := sertifikatBerlaku(ctx, sertifikat)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to verify sertifikat"})
+	}
+	return c.JSON(VerifikasiSertifikatResponse{
+		Valid:         valid,
+		Serial:        sertifikat.Serial,
+		NamaPeserta:   sertifikat.NamaPeserta,
+		JudulKegiatan: sertifikat.JudulKegiatan,
+		Tanggal:       formatTanggalSertifikat(sertifikat.Tanggal),
+		UKM:           sertifikat.UKM,
+		IssuedAt:      sertifikat.IssuedAt,
+	})
+}
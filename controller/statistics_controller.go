@@ -2,9 +2,11 @@ package controller
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson"
@@ -15,15 +17,36 @@ type StatisticsResponse struct {
 	TotalKegiatan    int64            `json:"totalKegiatan"`
 	TotalAnggota     int64            `json:"totalAnggota"`
 	TotalKehadiran   int64            `json:"totalKehadiran"`
+	Kehadiran        KehadiranStats   `json:"kehadiran"`
+	TotalTamu        int64            `json:"totalTamu"`
+	TotalTamuHadir   int64            `json:"totalTamuHadir"`
 	KegiatanByStatus map[string]int64 `json:"kegiatanByStatus"`
 	KegiatanByUkm    []UkmStats       `json:"kegiatanByUkm"`
 	MembersByUkm     []MemberStats    `json:"membersByUkm"`
 	RecentActivities []ActivityStats  `json:"recentActivities"`
+	Feedback         FeedbackStats    `json:"feedback"`
 }
 
+// KehadiranStats memisahkan ketidakhadiran dengan izin/sakit yang disetujui (excused)
+// dari alpa (unexcused). Data yang masih menunggu verifikasi dihitung terpisah.
+type KehadiranStats struct {
+	Present        int64   `json:"present"`
+	Late           int64   `json:"late"`
+	Permission     int64   `json:"permission"`
+	Sick           int64   `json:"sick"`
+	Excused        int64   `json:"excused"`
+	Unexcused      int64   `json:"unexcused"`
+	PendingReview  int64   `json:"pendingReview"`
+	AttendanceRate float64 `json:"attendanceRate"`
+}
+
+// UkmStats menghitung kegiatan untuk setiap UKM penyelenggara. Kegiatan bersama
+// muncul di semua UKM tuan rumah, sehingga jumlah persentase bisa melebihi 100.
 type UkmStats struct {
 	UKM        string  `json:"ukm"`
 	Count      int64   `json:"count"`
+	CoHosted   int64   `json:"coHosted"`
+	Attendees  int64   `json:"attendees"`
 	Percentage float64 `json:"percentage"`
 }
 
@@ -34,11 +57,25 @@ type MemberStats struct {
 	Total       int64  `json:"total"`
 }
 
+type FeedbackStats struct {
+	TotalFeedback      int64              `json:"totalFeedback"`
+	AverageRating      float64            `json:"averageRating"`
+	RatingDistribution map[string]int64   `json:"ratingDistribution"`
+	ByUkm              []UkmFeedbackStats `json:"byUkm"`
+}
+
+type UkmFeedbackStats struct {
+	UKM           string  `json:"ukm"`
+	Count         int64   `json:"count"`
+	AverageRating float64 `json:"averageRating"`
+}
+
 type ActivityStats struct {
 	Title     string `json:"title"`
 	UKM       string `json:"ukm"`
 	Date      string `json:"date"`
 	Attendees int64  `json:"attendees"`
+	Guests    int64  `json:"guests"`
 }
 
 // @Security BearerAuth
@@ -56,26 +93,109 @@ func GetStatistics(c *fiber.Ctx) error {
 	var stats StatisticsResponse
 
 	// Get total counts
-	totalKegiatan, err := config.DB.Collection("kegiatan").CountDocuments(ctx, bson.M{})
+	totalKegiatan, err := config.DB.Collection("kegiatan").CountDocuments(ctx, notDeleted(bson.M{}))
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to count kegiatan"})
 	}
 	stats.TotalKegiatan = totalKegiatan
 
-	totalAnggota, err := config.DB.Collection("users").CountDocuments(ctx, bson.M{})
+	totalAnggota, err := config.DB.Collection("users").CountDocuments(ctx, notDeleted(bson.M{}))
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to count users"})
 	}
 	stats.TotalAnggota = totalAnggota
 
-	totalKehadiran, err := config.DB.Collection("kehadiran").CountDocuments(ctx, bson.M{})
+	totalKehadiran, err := config.DB.Collection("kehadiran").CountDocuments(ctx, notDeleted(bson.M{}))
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to count kehadiran"})
 	}
 	stats.TotalKehadiran = totalKehadiran
 
-	// Get kegiatan by status
+	// Get kehadiran by status
+	cursor, err := config.DB.Collection("kehadiran").Aggregate(ctx, []bson.M{
+		{"$match": notDeleted(bson.M{})},
+		{
+			"$group": bson.M{
+				"_id": bson.M{
+					"status":  "$status",
+					"pending": bson.M{"$eq": []interface{}{"$verifikasi", model.VerifikasiPending}},
+				},
+				"count": bson.M{"$sum": 1},
+			},
+		},
+	})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to aggregate kehadiran"})
+	}
+	var kehadiranResults []struct {
+		ID struct {
+			Status  string `bson:"status"`
+			Pending bool   `bson:"pending"`
+		} `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &kehadiranResults); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode kehadiran results"})
+	}
+	perStatus := map[string]int64{}
+	for _, result := range kehadiranResults {
+		if result.ID.Pending {
+			stats.Kehadiran.PendingReview += result.Count
+			continue
+		}
+		perStatus[result.ID.Status] += result.Count
+	}
+	stats.Kehadiran.Present = perStatus[model.StatusHadir]
+	stats.Kehadiran.Late = perStatus[model.StatusTerlambat]
+	stats.Kehadiran.Permission = perStatus[model.StatusIzin]
+	stats.Kehadiran.Sick = perStatus[model.StatusSakit]
+	stats.Kehadiran.Excused = stats.Kehadiran.Permission + stats.Kehadiran.Sick
+	stats.Kehadiran.Unexcused = perStatus[model.StatusAlpa] + perStatus[model.StatusTidak]
+	stats.Kehadiran.AttendanceRate = tingkatKehadiran(perStatus)
+
+	// Get guest counts, tamu dari kegiatan yang dihapus tidak dihitung
 	pipeline := []bson.M{
+		{
+			"$lookup": bson.M{
+				"from":         "kegiatan",
+				"localField":   "kegiatan_id",
+				"foreignField": "_id",
+				"as":           "kegiatan",
+			},
+		},
+		{"$unwind": "$kegiatan"},
+		{"$match": bson.M{"kegiatan.deleted_at": nil}},
+		{
+			"$group": bson.M{
+				"_id":   nil,
+				"total": bson.M{"$sum": 1},
+				"hadir": bson.M{"$sum": bson.M{"$cond": []interface{}{bson.M{"$eq": []interface{}{"$status_kehadiran", "hadir"}}, 1, 0}}},
+			},
+		},
+	}
+
+	cursor, err = config.DB.Collection("tamu").Aggregate(ctx, pipeline)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to aggregate tamu"})
+	}
+
+	var tamuResults []struct {
+		Total int64 `bson:"total"`
+		Hadir int64 `bson:"hadir"`
+	}
+	if err := cursor.All(ctx, &tamuResults); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode tamu results"})
+	}
+	if len(tamuResults) > 0 {
+		stats.TotalTamu = tamuResults[0].Total
+		stats.TotalTamuHadir = tamuResults[0].Hadir
+	}
+
+	// Get kegiatan by status
+	pipeline = []bson.M{
+		{
+			"$match": notDeleted(bson.M{}),
+		},
 		{
 			"$group": bson.M{
 				"_id":   "$status",
@@ -84,7 +204,7 @@ func GetStatistics(c *fiber.Ctx) error {
 		},
 	}
 
-	cursor, err := config.DB.Collection("kegiatan").Aggregate(ctx, pipeline)
+	cursor, err = config.DB.Collection("kegiatan").Aggregate(ctx, pipeline)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to aggregate kegiatan by status"})
 	}
@@ -108,12 +228,56 @@ func GetStatistics(c *fiber.Ctx) error {
 		}
 	}
 
-	// Get kegiatan by UKM
+	// Get kegiatan by UKM, kegiatan bersama dihitung untuk tuan rumah dan setiap co-host
 	pipeline = []bson.M{
+		{
+			"$match": notDeleted(bson.M{}),
+		},
+		{
+			"$lookup": bson.M{
+				"from": "kehadiran",
+				"let":  bson.M{"kegiatan_id": "$_id"},
+				"pipeline": []bson.M{
+					{
+						"$match": filterHadir(notDeleted(bson.M{
+							"$expr": bson.M{
+								"$eq": []interface{}{"$kegiatan_id", "$$kegiatan_id"},
+							},
+						})),
+					},
+					{
+						"$count": "total",
+					},
+				},
+				"as": "kehadiran_count",
+			},
+		},
+		{
+			"$project": bson.M{
+				"kategori": 1,
+				"hosts": bson.M{
+					"$setUnion": []interface{}{
+						[]interface{}{"$kategori"},
+						bson.M{"$ifNull": []interface{}{"$co_hosts", []interface{}{}}},
+					},
+				},
+				"attendees": bson.M{
+					"$ifNull": []interface{}{
+						bson.M{"$arrayElemAt": []interface{}{"$kehadiran_count.total", 0}},
+						0,
+					},
+				},
+			},
+		},
+		{
+			"$unwind": "$hosts",
+		},
 		{
 			"$group": bson.M{
-				"_id":   "$kategori",
-				"count": bson.M{"$sum": 1},
+				"_id":       "$hosts",
+				"count":     bson.M{"$sum": 1},
+				"co_hosted": bson.M{"$sum": bson.M{"$cond": []interface{}{bson.M{"$ne": []interface{}{"$hosts", "$kategori"}}, 1, 0}}},
+				"attendees": bson.M{"$sum": "$attendees"},
 			},
 		},
 		{
@@ -126,30 +290,40 @@ func GetStatistics(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to aggregate kegiatan by UKM"})
 	}
 
-	var ukmResults []bson.M
+	var ukmResults []struct {
+		UKM       string `bson:"_id"`
+		Count     int64  `bson:"count"`
+		CoHosted  int64  `bson:"co_hosted"`
+		Attendees int64  `bson:"attendees"`
+	}
 	if err := cursor.All(ctx, &ukmResults); err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode UKM results"})
 	}
 
+	// Total kegiatan dan kehadiran tetap dihitung sekali per dokumen di atas
 	stats.KegiatanByUkm = make([]UkmStats, 0)
 	for _, result := range ukmResults {
-		if ukm, ok := result["_id"].(string); ok && ukm != "" {
-			if count, ok := result["count"].(int32); ok {
-				percentage := float64(0)
-				if totalKegiatan > 0 {
-					percentage = (float64(count) / float64(totalKegiatan)) * 100
-				}
-				stats.KegiatanByUkm = append(stats.KegiatanByUkm, UkmStats{
-					UKM:        ukm,
-					Count:      int64(count),
-					Percentage: percentage,
-				})
-			}
+		if result.UKM == "" {
+			continue
+		}
+		percentage := float64(0)
+		if totalKegiatan > 0 {
+			percentage = (float64(result.Count) / float64(totalKegiatan)) * 100
 		}
+		stats.KegiatanByUkm = append(stats.KegiatanByUkm, UkmStats{
+			UKM:        result.UKM,
+			Count:      result.Count,
+			CoHosted:   result.CoHosted,
+			Attendees:  result.Attendees,
+			Percentage: percentage,
+		})
 	}
 
 	// Get members by UKM
 	pipeline = []bson.M{
+		{
+			"$match": notDeleted(bson.M{}),
+		},
 		{
 			"$group": bson.M{
 				"_id": bson.M{
@@ -200,6 +374,9 @@ func GetStatistics(c *fiber.Ctx) error {
 
 	// Get recent activities
 	pipeline = []bson.M{
+		{
+			"$match": notDeleted(bson.M{}),
+		},
 		{
 			"$sort": bson.M{"tanggal": -1},
 		},
@@ -209,14 +386,14 @@ func GetStatistics(c *fiber.Ctx) error {
 		{
 			"$lookup": bson.M{
 				"from": "kehadiran",
-				"let":  bson.M{"kegiatan_id": bson.M{"$toString": "$_id"}},
+				"let":  bson.M{"kegiatan_id": "$_id"},
 				"pipeline": []bson.M{
 					{
-						"$match": bson.M{
+						"$match": filterHadir(notDeleted(bson.M{
 							"$expr": bson.M{
 								"$eq": []interface{}{"$kegiatan_id", "$$kegiatan_id"},
 							},
-						},
+						})),
 					},
 					{
 						"$count": "total",
@@ -225,6 +402,24 @@ func GetStatistics(c *fiber.Ctx) error {
 				"as": "kehadiran_count",
 			},
 		},
+		{
+			"$lookup": bson.M{
+				"from": "tamu",
+				"let":  bson.M{"kegiatan_id": "$_id"},
+				"pipeline": []bson.M{
+					{
+						"$match": bson.M{
+							"$expr":            bson.M{"$eq": []interface{}{"$kegiatan_id", "$$kegiatan_id"}},
+							"status_kehadiran": "hadir",
+						},
+					},
+					{
+						"$count": "total",
+					},
+				},
+				"as": "tamu_count",
+			},
+		},
 		{
 			"$project": bson.M{
 				"judul":    1,
@@ -236,6 +431,12 @@ func GetStatistics(c *fiber.Ctx) error {
 						0,
 					},
 				},
+				"guests_count": bson.M{
+					"$ifNull": []interface{}{
+						bson.M{"$arrayElemAt": []interface{}{"$tamu_count.total", 0}},
+						0,
+					},
+				},
 			},
 		},
 	}
@@ -266,9 +467,35 @@ func GetStatistics(c *fiber.Ctx) error {
 		if attendees, ok := result["attendees_count"].(int32); ok {
 			activity.Attendees = int64(attendees)
 		}
+		if guests, ok := result["guests_count"].(int32); ok {
+			activity.Guests = int64(guests)
+		}
 
 		stats.RecentActivities = append(stats.RecentActivities, activity)
 	}
 
+	// Get feedback summary
+	feedbackPerUKM, err := hitungFeedbackPerUKM(ctx, "")
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to aggregate feedback"})
+	}
+
+	total := newRingkasanFeedback()
+	stats.Feedback.ByUkm = make([]UkmFeedbackStats, 0)
+	for _, ukm := range feedbackPerUKM {
+		for rating, count := range ukm.Distribusi {
+			value, _ := strconv.Atoi(rating)
+			tambahRating(&total, value, count)
+		}
+		stats.Feedback.ByUkm = append(stats.Feedback.ByUkm, UkmFeedbackStats{
+			UKM:           ukm.UKM,
+			Count:         ukm.JumlahFeedback,
+			AverageRating: ukm.RataRata,
+		})
+	}
+	stats.Feedback.TotalFeedback = total.JumlahFeedback
+	stats.Feedback.AverageRating = total.RataRata
+	stats.Feedback.RatingDistribution = total.Distribusi
+
 	return c.JSON(stats)
 }
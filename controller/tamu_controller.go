@@ -0,0 +1,414 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var tamuValidate = validator.New()
+
+type DaftarTamuRequest struct {
+	Nama      string `json:"nama" validate:"required,min=2,max=100"`
+	Email     string `json:"email" validate:"required,email"`
+	Institusi string `json:"institusi" validate:"max=150"`
+	NoHP      string `json:"no_hp" validate:"max=20"`
+}
+
+type KehadiranTamuRequest struct {
+	Status string `json:"status" validate:"required,oneof=hadir tidak"`
+}
+
+type KonversiTamuRequest struct {
+	Password string `json:"password" validate:"required,min=6"`
+	UKM      string `json:"ukm" validate:"required"`
+}
+
+// findTamu mengambil tamu milik kegiatan tertentu
+func findTamu(ctx context.Context, c *fiber.Ctx, kegiatanID primitive.ObjectID) (model.Tamu, error) {
+	var tamu model.Tamu
+	tamuID, err := primitive.ObjectIDFromHex(c.Params("tamuId"))
+	if err != nil {
+		return tamu, errInvalidID
+	}
+	err = config.DB.Collection("tamu").FindOne(ctx, bson.M{"_id": tamuID, "kegiatan_id": kegiatanID}).Decode(&tamu)
+	return tamu, err
+}
+
+func tamuErrorResponse(c *fiber.Ctx, err error) error {
+	switch err {
+	case errInvalidID:
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid tamu ID"})
+	case mongo.ErrNoDocuments:
+		return c.Status(404).JSON(fiber.Map{"error": "Tamu not found"})
+	default:
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch tamu"})
+	}
+}
+
+var errBukanAdminUKM = errors.New("not an admin of the hosting UKMs")
+
+// kegiatanForTamuAdmin mengambil kegiatan dan memastikan user login adalah admin UKM penyelenggara
+func kegiatanForTamuAdmin(ctx context.Context, c *fiber.Ctx) (model.Kegiatan, error) {
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatan, err
+	}
+	allowed, err := canManageKegiatan(ctx, c, kegiatan)
+	if err != nil {
+		return kegiatan, err
+	}
+	if !allowed {
+		return kegiatan, errBukanAdminUKM
+	}
+	return kegiatan, nil
+}
+
+func tamuAdminErrorResponse(c *fiber.Ctx, err error) error {
+	if err == errBukanAdminUKM {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the hosting UKMs can manage tamu"})
+	}
+	return kegiatanErrorResponse(c, err)
+}
+
+// DaftarTamu godoc
+// @Summary Register as a guest for a public kegiatan
+// @Description Pendaftaran tanpa login untuk peserta dari luar UKM. Hanya untuk kegiatan publik yang sudah disetujui.
+// @Tags Tamu
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param tamu body DaftarTamuRequest true "Data tamu"
+// @Success 201 {object} model.Tamu
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /public/kegiatan/{id}/tamu [post]
+func DaftarTamu(c *fiber.Ctx) error {
+	var input DaftarTamuRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	input.Email = strings.ToLower(strings.TrimSpace(input.Email))
+	if err := tamuValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	// Kegiatan internal tidak ditampilkan ke publik
+	if !kegiatan.Publik || approvalStatusOf(kegiatan) != model.KegiatanApproved {
+		return c.Status(404).JSON(fiber.Map{"error": "Kegiatan not found"})
+	}
+
+	count, err := config.DB.Collection("users").CountDocuments(ctx, notDeleted(bson.M{"email": input.Email}))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate email"})
+	}
+	if count > 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "Email is registered as a member, please log in"})
+	}
+	if kegiatan.MaxParticipants > 0 {
+		count, err := config.DB.Collection("tamu").CountDocuments(ctx, bson.M{"kegiatan_id": kegiatan.ID})
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to check capacity"})
+		}
+		if count >= int64(kegiatan.MaxParticipants) {
+			return c.Status(409).JSON(fiber.Map{"error": "Kegiatan is full"})
+		}
+	}
+
+	now := time.Now()
+	tamu := model.Tamu{
+		KegiatanID: kegiatan.ID,
+		Nama:       input.Nama,
+		Email:      input.Email,
+		Institusi:  input.Institusi,
+		NoHP:       input.NoHP,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	res, err := config.DB.Collection("tamu").InsertOne(ctx, tamu)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Email already registered for this kegiatan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to register tamu"})
+	}
+	tamu.ID = res.InsertedID.(primitive.ObjectID)
+	return c.Status(201).JSON(tamu)
+}
+
+// GetTamu godoc
+// @Summary Get guests of a kegiatan
+// @Description Hanya reviewer dan admin UKM penyelenggara kegiatan
+// @Tags Tamu
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param status_kehadiran query string false "Filter status kehadiran (hadir, tidak)"
+// @Success 200 {array} model.Tamu
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tamu [get]
+// @Security BearerAuth
+func GetTamu(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var kegiatan model.Kegiatan
+	var err error
+	if utils.GetUserRole(c) == "reviewer" {
+		kegiatan, err = findKegiatanByID(ctx, c.Params("id"))
+	} else {
+		kegiatan, err = kegiatanForTamuAdmin(ctx, c)
+	}
+	if err != nil {
+		return tamuAdminErrorResponse(c, err)
+	}
+	filter := bson.M{"kegiatan_id": kegiatan.ID}
+	if status := c.Query("status_kehadiran"); status != "" {
+		filter["status_kehadiran"] = status
+	}
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+	cursor, err := config.DB.Collection("tamu").Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch tamu"})
+	}
+	tamu := []model.Tamu{}
+	if err := cursor.All(ctx, &tamu); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode tamu"})
+	}
+	return c.JSON(tamu)
+}
+
+// UpdateKehadiranTamu godoc
+// @Summary Record guest attendance
+// @Tags Tamu
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param tamuId path string true "Tamu ID"
+// @Param body body KehadiranTamuRequest true "Status kehadiran"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tamu/{tamuId}/kehadiran [put]
+// @Security BearerAuth
+func UpdateKehadiranTamu(c *fiber.Ctx) error {
+	var input KehadiranTamuRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := tamuValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := kegiatanForTamuAdmin(ctx, c)
+	if err != nil {
+		return tamuAdminErrorResponse(c, err)
+	}
+	tamu, err := findTamu(ctx, c, kegiatan.ID)
+	if err != nil {
+		return tamuErrorResponse(c, err)
+	}
+	now := time.Now()
+	set := bson.M{"status_kehadiran": input.Status, "updated_by": utils.GetUserID(c), "updated_at": now}
+	if input.Status == "hadir" {
+		set["waktu_cek"] = now.Format(time.RFC3339)
+	} else {
+		set["waktu_cek"] = ""
+	}
+	if _, err := config.DB.Collection("tamu").UpdateOne(ctx, bson.M{"_id": tamu.ID}, bson.M{"$set": set}); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update kehadiran tamu"})
+	}
+	return c.JSON(fiber.Map{"message": "Kehadiran tamu updated"})
+}
+
+// DeleteTamu godoc
+// @Summary Remove a guest registration
+// @Tags Tamu
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param tamuId path string true "Tamu ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tamu/{tamuId} [delete]
+// @Security BearerAuth
+func DeleteTamu(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := kegiatanForTamuAdmin(ctx, c)
+	if err != nil {
+		return tamuAdminErrorResponse(c, err)
+	}
+	tamu, err := findTamu(ctx, c, kegiatan.ID)
+	if err != nil {
+		return tamuErrorResponse(c, err)
+	}
+	if _, err := config.DB.Collection("tamu").DeleteOne(ctx, bson.M{"_id": tamu.ID}); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete tamu"})
+	}
+	return c.JSON(fiber.Map{"message": "Tamu deleted"})
+}
+
+// KonversiTamu godoc
+// @Summary Convert a guest into a member account
+// @Description Membuat akun member dari data tamu. Semua kehadiran tamu dengan email yang sama dipindahkan ke kehadiran member.
+// @Tags Tamu
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param tamuId path string true "Tamu ID"
+// @Param body body KonversiTamuRequest true "Password awal dan UKM"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tamu/{tamuId}/convert [post]
+// @Security BearerAuth
+func KonversiTamu(c *fiber.Ctx) error {
+	var input KonversiTamuRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := tamuValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	kegiatan, err := kegiatanForTamuAdmin(ctx, c)
+	if err != nil {
+		return tamuAdminErrorResponse(c, err)
+	}
+	tamu, err := findTamu(ctx, c, kegiatan.ID)
+	if err != nil {
+		return tamuErrorResponse(c, err)
+	}
+	if !tamu.ConvertedUserID.IsZero() {
+		return c.Status(409).JSON(fiber.Map{"error": "Tamu already converted"})
+	}
+
+	// Aturan sama dengan Register: email unik dan UKM harus ada di kategori
+	count, err := config.DB.Collection("users").CountDocuments(ctx, bson.M{"email": tamu.Email})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate email"})
+	}
+	if count > 0 {
+		return c.Status(409).JSON(fiber.Map{"error": "Email already registered"})
+	}
+	kategoriCount, err := config.DB.Collection("kategori").CountDocuments(ctx, notDeleted(bson.M{"nama_kategori": input.UKM}))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate UKM"})
+	}
+	if kategoriCount == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "UKM tidak valid, pilih dari daftar kategori"})
+	}
+	hash, err := utils.HashPassword(input.Password)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to hash password"})
+	}
+
+	now := time.Now()
+	adminID := utils.GetUserID(c)
+	res, err := config.DB.Collection("users").InsertOne(ctx, model.User{
+		Nama:      tamu.Nama,
+		Email:     tamu.Email,
+		Password:  hash,
+		Role:      "member",
+		UKM:       input.UKM,
+		CreatedBy: adminID,
+		CreatedAt: now,
+		UpdatedBy: adminID,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create user"})
+	}
+	userID := res.InsertedID.(primitive.ObjectID)
+	// Jika langkah berikutnya gagal, user dan kehadiran yang sudah dibuat dihapus kembali
+	// agar konversi bisa diulang dari awal
+	batalkan := func() {
+		rollbackCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := config.DB.Collection("tamu").UpdateMany(rollbackCtx, bson.M{"converted_user_id": userID}, bson.M{"$unset": bson.M{"converted_user_id": ""}}); err != nil {
+			log.Println("Gagal membatalkan konversi tamu", tamu.Email, ":", err)
+		}
+		if _, err := config.DB.Collection("kehadiran").DeleteMany(rollbackCtx, bson.M{"user_id": userID}); err != nil {
+			log.Println("Gagal membatalkan konversi tamu", tamu.Email, ":", err)
+		}
+		if _, err := config.DB.Collection("users").DeleteOne(rollbackCtx, bson.M{"_id": userID}); err != nil {
+			log.Println("Gagal membatalkan konversi tamu", tamu.Email, ":", err)
+		}
+	}
+
+	// Pindahkan riwayat kehadiran tamu (semua kegiatan) ke kehadiran member
+	cursor, err := config.DB.Collection("tamu").Find(ctx, bson.M{"email": tamu.Email, "status_kehadiran": bson.M{"$in": []string{"hadir", "tidak"}}})
+	if err != nil {
+		batalkan()
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch riwayat tamu"})
+	}
+	var riwayat []model.Tamu
+	if err := cursor.All(ctx, &riwayat); err != nil {
+		batalkan()
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode riwayat tamu"})
+	}
+	docs := make([]interface{}, 0, len(riwayat))
+	for _, r := range riwayat {
+		docs = append(docs, model.Kehadiran{
+			UserID:     userID,
+			KegiatanID: r.KegiatanID,
+			Status:     r.StatusKehadiran,
+			WaktuCek:   r.WaktuCek,
+			Metode:     model.MetodeAdmin,
+			CreatedBy:  adminID,
+			CreatedAt:  now,
+			UpdatedBy:  adminID,
+			UpdatedAt:  now,
+		})
+	}
+	if len(docs) > 0 {
+		if _, err := config.DB.Collection("kehadiran").InsertMany(ctx, docs); err != nil {
+			batalkan()
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to migrate kehadiran"})
+		}
+	}
+	_, err = config.DB.Collection("tamu").UpdateMany(ctx, bson.M{"email": tamu.Email}, bson.M{
+		"$set": bson.M{"converted_user_id": userID, "updated_by": adminID, "updated_at": now},
+	})
+	if err != nil {
+		batalkan()
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update tamu"})
+	}
+	return c.Status(201).JSON(fiber.Map{
+		"message":               "Tamu converted to member",
+		"user_id":               userID.Hex(),
+		"kehadiran_dipindahkan": len(docs),
+	})
+}
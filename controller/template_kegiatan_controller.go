@@ -0,0 +1,449 @@
+package controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var templateValidate = validator.New()
+
+// DuplikatKegiatanRequest berisi tanggal baru, judul boleh dikosongkan untuk memakai judul sumber
+type DuplikatKegiatanRequest struct {
+	Tanggal string `json:"tanggal" validate:"required"`
+	Judul   string `json:"judul"`
+}
+
+type SimpanTemplateRequest struct {
+	Nama string `json:"nama" validate:"required"`
+}
+
+// snapshotKegiatan menyalin detail, panitia, dan rencana anggaran kegiatan menjadi template
+func snapshotKegiatan(ctx context.Context, kegiatan model.Kegiatan) (model.TemplateKegiatan, error) {
+	template := model.TemplateKegiatan{
+		UKM:             kegiatan.Kategori,
+		CoHosts:         kegiatan.CoHosts,
+		Judul:           kegiatan.Judul,
+		Deskripsi:       kegiatan.Deskripsi,
+		Lokasi:          kegiatan.Lokasi,
+		Latitude:        kegiatan.Latitude,
+		Longitude:       kegiatan.Longitude,
+		RadiusMeter:     kegiatan.RadiusMeter,
+		MaxParticipants: kegiatan.MaxParticipants,
+		JendelaCheckin:  kegiatan.JendelaCheckin,
+		Publik:          kegiatan.Publik,
+		Panitia:         []model.TemplatePanitia{},
+		Anggaran:        []model.TemplateAnggaran{},
+	}
+
+	cursor, err := config.DB.Collection("panitia").Find(ctx, bson.M{"kegiatan_id": kegiatan.ID})
+	if err != nil {
+		return template, err
+	}
+	var panitia []model.Panitia
+	if err := cursor.All(ctx, &panitia); err != nil {
+		return template, err
+	}
+	for _, p := range panitia {
+		template.Panitia = append(template.Panitia, model.TemplatePanitia{UserID: p.UserID, Peran: p.Peran, Divisi: p.Divisi})
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+	cursor, err = config.DB.Collection("anggaran").Find(ctx, bson.M{"kegiatan_id": kegiatan.ID, "jenis": model.AnggaranRencana}, opts)
+	if err != nil {
+		return template, err
+	}
+	var items []model.AnggaranItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return template, err
+	}
+	for _, item := range items {
+		template.Anggaran = append(template.Anggaran, model.TemplateAnggaran{Kategori: item.Kategori, Uraian: item.Uraian, Jumlah: item.Jumlah})
+	}
+	return template, nil
+}
+
+// buatKegiatanDariTemplate membuat kegiatan draft baru beserta panitia dan rencana anggarannya.
+// Panitia yang akunnya sudah dihapus tidak ikut disalin. Pesan validasi co-host dikembalikan
+// sebagai string, dan kegiatan yang sudah tersimpan dibatalkan jika langkah berikutnya gagal.
+func buatKegiatanDariTemplate(ctx context.Context, template model.TemplateKegiatan, input DuplikatKegiatanRequest, userID string) (model.Kegiatan, string, error) {
+	now := time.Now()
+	kegiatan := model.Kegiatan{
+		Judul:           template.Judul,
+		Deskripsi:       template.Deskripsi,
+		Tanggal:         input.Tanggal,
+		Lokasi:          template.Lokasi,
+		Latitude:        template.Latitude,
+		Longitude:       template.Longitude,
+		RadiusMeter:     template.RadiusMeter,
+		Kategori:        template.UKM,
+		CoHosts:         template.CoHosts,
+		MaxParticipants: template.MaxParticipants,
+		JendelaCheckin:  template.JendelaCheckin,
+		Publik:          template.Publik,
+		ApprovalStatus:  model.KegiatanDraft,
+		CreatedBy:       userID,
+		CreatedAt:       now,
+		UpdatedBy:       userID,
+		UpdatedAt:       now,
+	}
+	if input.Judul != "" {
+		kegiatan.Judul = input.Judul
+	}
+	// Co-host di template bisa saja sudah dihapus sejak template disimpan
+	if msg, err := validateHosts(ctx, &kegiatan); err != nil || msg != "" {
+		return kegiatan, msg, err
+	}
+	res, err := config.DB.Collection("kegiatan").InsertOne(ctx, kegiatan)
+	if err != nil {
+		return kegiatan, "", err
+	}
+	kegiatan.ID = res.InsertedID.(primitive.ObjectID)
+	batalkan := func() {
+		rollbackCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := config.DB.Collection("panitia").DeleteMany(rollbackCtx, bson.M{"kegiatan_id": kegiatan.ID}); err != nil {
+			log.Println("Gagal membatalkan kegiatan dari template", kegiatan.ID.Hex(), ":", err)
+		}
+		if _, err := config.DB.Collection("anggaran").DeleteMany(rollbackCtx, bson.M{"kegiatan_id": kegiatan.ID}); err != nil {
+			log.Println("Gagal membatalkan kegiatan dari template", kegiatan.ID.Hex(), ":", err)
+		}
+		if _, err := config.DB.Collection("kegiatan").DeleteOne(rollbackCtx, bson.M{"_id": kegiatan.ID}); err != nil {
+			log.Println("Gagal membatalkan kegiatan dari template", kegiatan.ID.Hex(), ":", err)
+		}
+	}
+
+	if len(template.Panitia) > 0 {
+		candidates := make([]primitive.ObjectID, 0, len(template.Panitia))
+		for _, p := range template.Panitia {
+			candidates = append(candidates, p.UserID)
+		}
+		activeIDs, err := findUserIDs(ctx, bson.M{"_id": bson.M{"$in": candidates}})
+		if err != nil {
+			batalkan()
+			return kegiatan, "", err
+		}
+		active := map[primitive.ObjectID]bool{}
+		for _, id := range activeIDs {
+			active[id] = true
+		}
+		docs := []interface{}{}
+		for _, p := range template.Panitia {
+			if !active[p.UserID] {
+				continue
+			}
+			docs = append(docs, model.Panitia{
+				KegiatanID: kegiatan.ID,
+				UserID:     p.UserID,
+				Peran:      p.Peran,
+				Divisi:     p.Divisi,
+				CreatedBy:  userID,
+				CreatedAt:  now,
+				UpdatedBy:  userID,
+				UpdatedAt:  now,
+			})
+		}
+		if len(docs) > 0 {
+			if _, err := config.DB.Collection("panitia").InsertMany(ctx, docs); err != nil {
+				batalkan()
+				return kegiatan, "", err
+			}
+		}
+	}
+
+	if len(template.Anggaran) > 0 {
+		docs := make([]interface{}, 0, len(template.Anggaran))
+		for _, a := range template.Anggaran {
+			docs = append(docs, model.AnggaranItem{
+				KegiatanID: kegiatan.ID,
+				Jenis:      model.AnggaranRencana,
+				Kategori:   a.Kategori,
+				Uraian:     a.Uraian,
+				Jumlah:     a.Jumlah,
+				CreatedBy:  userID,
+				CreatedAt:  now,
+				UpdatedBy:  userID,
+				UpdatedAt:  now,
+			})
+		}
+		if _, err := config.DB.Collection("anggaran").InsertMany(ctx, docs); err != nil {
+			batalkan()
+			return kegiatan, "", err
+		}
+	}
+	return kegiatan, "", nil
+}
+
+// parseDuplikatRequest membaca body dan mengembalikan pesan error validasi jika ada
+func parseDuplikatRequest(c *fiber.Ctx) (DuplikatKegiatanRequest, string) {
+	var input DuplikatKegiatanRequest
+	if err := c.BodyParser(&input); err != nil {
+		return input, "Invalid request body"
+	}
+	if err := templateValidate.Struct(input); err != nil {
+		return input, err.Error()
+	}
+	if _, err := utils.ParseTanggal(input.Tanggal); err != nil {
+		return input, "Invalid tanggal format"
+	}
+	return input, ""
+}
+
+// DuplicateKegiatan godoc
+// @Summary Duplicate a kegiatan
+// @Description Menyalin deskripsi, lokasi, kategori, kapasitas, panitia, dan rencana anggaran ke kegiatan draft baru dengan tanggal baru
+// @Tags Template Kegiatan
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param body body DuplikatKegiatanRequest true "Tanggal dan judul baru"
+// @Success 201 {object} KegiatanResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/duplicate [post]
+// @Security BearerAuth
+func DuplicateKegiatan(c *fiber.Ctx) error {
+	input, msg := parseDuplikatRequest(c)
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sumber, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageUKM(ctx, c, sumber.Kategori)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the owning UKM can duplicate this kegiatan"})
+	}
+	template, err := snapshotKegiatan(ctx, sumber)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to read kegiatan"})
+	}
+	kegiatan, msg, err := buatKegiatanDariTemplate(ctx, template, input, utils.GetUserID(c))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to duplicate kegiatan"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	return c.Status(201).JSON(toKegiatanResponse(kegiatan))
+}
+
+// SaveKegiatanAsTemplate godoc
+// @Summary Save a kegiatan to the UKM template library
+// @Tags Template Kegiatan
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param body body SimpanTemplateRequest true "Nama template"
+// @Success 201 {object} model.TemplateKegiatan
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/template [post]
+// @Security BearerAuth
+func SaveKegiatanAsTemplate(c *fiber.Ctx) error {
+	var input SimpanTemplateRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := templateValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sumber, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManageUKM(ctx, c, sumber.Kategori)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the owning UKM can manage templates"})
+	}
+	template, err := snapshotKegiatan(ctx, sumber)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to read kegiatan"})
+	}
+	now := time.Now()
+	template.Nama = input.Nama
+	template.CreatedBy = utils.GetUserID(c)
+	template.CreatedAt = now
+	template.UpdatedBy = template.CreatedBy
+	template.UpdatedAt = now
+	res, err := config.DB.Collection("template_kegiatan").InsertOne(ctx, template)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Template name already used in this UKM"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to save template"})
+	}
+	template.ID = res.InsertedID.(primitive.ObjectID)
+	return c.Status(201).JSON(template)
+}
+
+// GetTemplateKegiatan godoc
+// @Summary Get kegiatan templates
+// @Tags Template Kegiatan
+// @Produce json
+// @Param ukm query string false "Filter UKM"
+// @Success 200 {array} model.TemplateKegiatan
+// @Failure 500 {object} map[string]interface{}
+// @Router /template-kegiatan [get]
+// @Security BearerAuth
+func GetTemplateKegiatan(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filter := bson.M{}
+	if ukm := c.Query("ukm"); ukm != "" {
+		filter["ukm"] = ukm
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "ukm", Value: 1}, {Key: "nama", Value: 1}})
+	cursor, err := config.DB.Collection("template_kegiatan").Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch templates"})
+	}
+	templates := []model.TemplateKegiatan{}
+	if err := cursor.All(ctx, &templates); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode templates"})
+	}
+	return c.JSON(templates)
+}
+
+func findTemplateKegiatan(ctx context.Context, id string) (model.TemplateKegiatan, error) {
+	var template model.TemplateKegiatan
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return template, errInvalidID
+	}
+	err = config.DB.Collection("template_kegiatan").FindOne(ctx, bson.M{"_id": objID}).Decode(&template)
+	return template, err
+}
+
+func templateErrorResponse(c *fiber.Ctx, err error) error {
+	switch err {
+	case errInvalidID:
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid template ID"})
+	case mongo.ErrNoDocuments:
+		return c.Status(404).JSON(fiber.Map{"error": "Template not found"})
+	default:
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch template"})
+	}
+}
+
+// GetTemplateKegiatanByID godoc
+// @Summary Get kegiatan template by ID
+// @Tags Template Kegiatan
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} model.TemplateKegiatan
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /template-kegiatan/{id} [get]
+// @Security BearerAuth
+func GetTemplateKegiatanByID(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	template, err := findTemplateKegiatan(ctx, c.Params("id"))
+	if err != nil {
+		return templateErrorResponse(c, err)
+	}
+	return c.JSON(template)
+}
+
+// CreateKegiatanFromTemplate godoc
+// @Summary Create a draft kegiatan from a template
+// @Tags Template Kegiatan
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param body body DuplikatKegiatanRequest true "Tanggal dan judul kegiatan"
+// @Success 201 {object} KegiatanResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /template-kegiatan/{id}/kegiatan [post]
+// @Security BearerAuth
+func CreateKegiatanFromTemplate(c *fiber.Ctx) error {
+	input, msg := parseDuplikatRequest(c)
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	template, err := findTemplateKegiatan(ctx, c.Params("id"))
+	if err != nil {
+		return templateErrorResponse(c, err)
+	}
+	allowed, err := canManageUKM(ctx, c, template.UKM)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the owning UKM can use this template"})
+	}
+	kegiatan, msg, err := buatKegiatanDariTemplate(ctx, template, input, utils.GetUserID(c))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create kegiatan"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+	return c.Status(201).JSON(toKegiatanResponse(kegiatan))
+}
+
+// DeleteTemplateKegiatan godoc
+// @Summary Delete a kegiatan template
+// @Tags Template Kegiatan
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /template-kegiatan/{id} [delete]
+// @Security BearerAuth
+func DeleteTemplateKegiatan(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	template, err := findTemplateKegiatan(ctx, c.Params("id"))
+	if err != nil {
+		return templateErrorResponse(c, err)
+	}
+	allowed, err := canManageUKM(ctx, c, template.UKM)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only admins of the owning UKM can manage templates"})
+	}
+	if _, err := config.DB.Collection("template_kegiatan").DeleteOne(ctx, bson.M{"_id": template.ID}); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete template"})
+	}
+	return c.JSON(fiber.Map{"message": "Template deleted"})
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Koleksi yang mendukung soft delete beserta label untuk pesan error
+var trashCollections = map[string]string{
+	"kegiatan":  "Kegiatan",
+	"kehadiran": "Kehadiran",
+	"kategori":  "Kategori",
+	"users":     "User",
+}
+
+// notDeleted menambahkan syarat "belum dihapus" pada filter query
+func notDeleted(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
+// restoreDocument mengembalikan dokumen dari tempat sampah
+func restoreDocument(c *fiber.Ctx, collection string) error {
+	label := trashCollections[collection]
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filter := bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+		"$set":   bson.M{"updated_by": utils.GetUserID(c), "updated_at": time.Now()},
+	}
+	res, err := config.DB.Collection(collection).UpdateOne(ctx, filter, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return c.Status(409).JSON(fiber.Map{"error": label + " conflicts with existing data and cannot be restored"})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to restore " + strings.ToLower(label)})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": label + " not found in trash"})
+	}
+	return c.JSON(fiber.Map{"message": label + " restored"})
+}
+
+// GetTrash godoc
+// @Summary Get soft-deleted data
+// @Description Menampilkan data yang sudah dihapus dan belum dibersihkan permanen. Tanpa parameter type, semua koleksi ditampilkan.
+// @Tags Trash
+// @Produce json
+// @Param type query string false "Jenis data (kegiatan, kehadiran, kategori, users)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /trash [get]
+// @Security BearerAuth
+func GetTrash(c *fiber.Ctx) error {
+	collections := []string{"kegiatan", "kehadiran", "kategori", "users"}
+	if t := c.Query("type"); t != "" {
+		if _, ok := trashCollections[t]; !ok {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid trash type"})
+		}
+		collections = []string{t}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result := fiber.Map{}
+	for _, collection := range collections {
+		opts := options.Find().SetSort(bson.M{"deleted_at": -1})
+		if collection == "users" {
+			// Hapus password dari response untuk keamanan
+			opts.SetProjection(bson.M{"password": 0})
+		}
+		cursor, err := config.DB.Collection(collection).Find(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}}, opts)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch trash"})
+		}
+		items := []bson.M{}
+		if err := cursor.All(ctx, &items); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to decode trash"})
+		}
+		result[collection] = items
+	}
+	if len(collections) == 1 {
+		return c.JSON(result[collections[0]])
+	}
+	return c.JSON(result)
+}
+
+// RestoreKegiatan godoc
+// @Summary Restore deleted kegiatan
+// @Tags Trash
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/restore [post]
+// @Security BearerAuth
+func RestoreKegiatan(c *fiber.Ctx) error {
+	return restoreDocument(c, "kegiatan")
+}
+
+// RestoreKehadiran godoc
+// @Summary Restore deleted kehadiran
+// @Tags Trash
+// @Produce json
+// @Param id path string true "Kehadiran ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kehadiran/{id}/restore [post]
+// @Security BearerAuth
+func RestoreKehadiran(c *fiber.Ctx) error {
+	return restoreDocument(c, "kehadiran")
+}
+
+// RestoreKategori godoc
+// @Summary Restore deleted kategori
+// @Tags Trash
+// @Produce json
+// @Param id path string true "Kategori ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kategori/{id}/restore [post]
+// @Security BearerAuth
+func RestoreKategori(c *fiber.Ctx) error {
+	return restoreDocument(c, "kategori")
+}
+
+// RestoreUser godoc
+// @Summary Restore deleted user
+// @Tags Trash
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users/{id}/restore [post]
+// @Security BearerAuth
+func RestoreUser(c *fiber.Ctx) error {
+	return restoreDocument(c, "users")
+}
@@ -0,0 +1,395 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UpdateStatusTugasRequest struct {
+	Status string `json:"status" validate:"required,oneof=todo in_progress done"`
+}
+
+// validateTugas memeriksa due date dan memastikan penanggung jawab adalah panitia kegiatan
+func validateTugas(ctx context.Context, tugas model.Tugas) (string, error) {
+	if tugas.DueDate != "" {
+		if _, err := utils.ParseTanggal(tugas.DueDate); err != nil {
+			return "Invalid due_date format", nil
+		}
+	}
+	if tugas.AssigneeID.IsZero() {
+		return "", nil
+	}
+	count, err := config.DB.Collection("panitia").CountDocuments(ctx, bson.M{"kegiatan_id": tugas.KegiatanID, "user_id": tugas.AssigneeID})
+	if err != nil {
+		return "", err
+	}
+	if count == 0 {
+		return "Assignee must be a member of the panitia", nil
+	}
+	return "", nil
+}
+
+// notifyAssignee memberi tahu penanggung jawab tugas yang baru ditunjuk
+func notifyAssignee(ctx context.Context, tugas model.Tugas, kegiatan model.Kegiatan) {
+	if tugas.AssigneeID.IsZero() {
+		return
+	}
+	notify(ctx, []primitive.ObjectID{tugas.AssigneeID}, "tugas_assigned", "Tugas baru",
+		fmt.Sprintf("Anda mendapat tugas \"%s\" untuk kegiatan \"%s\"", tugas.Judul, kegiatan.Judul), kegiatan.ID)
+}
+
+// GetTugas godoc
+// @Summary Get tasks of a kegiatan
+// @Tags Tugas
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param status query string false "Filter status (todo, in_progress, done)"
+// @Success 200 {array} model.Tugas
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tugas [get]
+// @Security BearerAuth
+func GetTugas(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	filter := bson.M{"kegiatan_id": kegiatan.ID}
+	if status := c.Query("status"); status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := config.DB.Collection("tugas").Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch tugas"})
+	}
+	tugas := []model.Tugas{}
+	if err := cursor.All(ctx, &tugas); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode tugas"})
+	}
+	return c.JSON(tugas)
+}
+
+// CreateTugas godoc
+// @Summary Create a task for a kegiatan
+// @Description Penanggung jawab (assignee_id) harus terdaftar sebagai panitia kegiatan
+// @Tags Tugas
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param tugas body model.Tugas true "Tugas"
+// @Success 201 {object} model.Tugas
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tugas [post]
+// @Security BearerAuth
+func CreateTugas(c *fiber.Ctx) error {
+	var tugas model.Tugas
+	if err := c.BodyParser(&tugas); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := panitiaValidate.Struct(tugas); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only UKM admins or ketua pelaksana can manage tugas"})
+	}
+	tugas.KegiatanID = kegiatan.ID
+	msg, err := validateTugas(ctx, tugas)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate tugas"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+
+	now := time.Now()
+	tugas.ID = primitive.NilObjectID
+	if tugas.Status == "" {
+		tugas.Status = model.TugasTodo
+	}
+	tugas.CreatedBy = utils.GetUserID(c)
+	tugas.CreatedAt = now
+	tugas.UpdatedBy = tugas.CreatedBy
+	tugas.UpdatedAt = now
+	res, err := config.DB.Collection("tugas").InsertOne(ctx, tugas)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create tugas"})
+	}
+	tugas.ID = res.InsertedID.(primitive.ObjectID)
+	notifyAssignee(ctx, tugas, kegiatan)
+	return c.Status(201).JSON(tugas)
+}
+
+// UpdateTugas godoc
+// @Summary Update a task
+// @Tags Tugas
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param tugasId path string true "Tugas ID"
+// @Param tugas body model.Tugas true "Tugas"
+// @Success 200 {object} model.Tugas
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tugas/{tugasId} [put]
+// @Security BearerAuth
+func UpdateTugas(c *fiber.Ctx) error {
+	tugasID, err := primitive.ObjectIDFromHex(c.Params("tugasId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid tugas ID"})
+	}
+	var input model.Tugas
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := panitiaValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only UKM admins or ketua pelaksana can manage tugas"})
+	}
+	input.KegiatanID = kegiatan.ID
+	msg, err := validateTugas(ctx, input)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to validate tugas"})
+	}
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+
+	var existing model.Tugas
+	filter := bson.M{"_id": tugasID, "kegiatan_id": kegiatan.ID}
+	if err := config.DB.Collection("tugas").FindOne(ctx, filter).Decode(&existing); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Tugas not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch tugas"})
+	}
+	if input.Status == "" {
+		input.Status = existing.Status
+	}
+	set := bson.M{
+		"judul":      input.Judul,
+		"deskripsi":  input.Deskripsi,
+		"due_date":   input.DueDate,
+		"status":     input.Status,
+		"updated_by": utils.GetUserID(c),
+		"updated_at": time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if input.AssigneeID.IsZero() {
+		update["$unset"] = bson.M{"assignee_id": ""}
+	} else {
+		set["assignee_id"] = input.AssigneeID
+	}
+	if _, err := config.DB.Collection("tugas").UpdateOne(ctx, filter, update); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update tugas"})
+	}
+
+	input.ID = existing.ID
+	input.CreatedBy = existing.CreatedBy
+	input.CreatedAt = existing.CreatedAt
+	input.UpdatedBy = set["updated_by"].(string)
+	input.UpdatedAt = set["updated_at"].(time.Time)
+	if input.AssigneeID != existing.AssigneeID {
+		notifyAssignee(ctx, input, kegiatan)
+	}
+	return c.JSON(input)
+}
+
+// UpdateStatusTugas godoc
+// @Summary Update task status
+// @Description Dapat dilakukan oleh penanggung jawab tugas, admin UKM, atau ketua pelaksana
+// @Tags Tugas
+// @Accept json
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param tugasId path string true "Tugas ID"
+// @Param status body UpdateStatusTugasRequest true "Status baru"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tugas/{tugasId}/status [patch]
+// @Security BearerAuth
+func UpdateStatusTugas(c *fiber.Ctx) error {
+	tugasID, err := primitive.ObjectIDFromHex(c.Params("tugasId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid tugas ID"})
+	}
+	var input UpdateStatusTugasRequest
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := panitiaValidate.Struct(input); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	var tugas model.Tugas
+	filter := bson.M{"_id": tugasID, "kegiatan_id": kegiatan.ID}
+	if err := config.DB.Collection("tugas").FindOne(ctx, filter).Decode(&tugas); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "Tugas not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch tugas"})
+	}
+	userID, _ := currentUserObjectID(c)
+	if tugas.AssigneeID.IsZero() || tugas.AssigneeID != userID {
+		allowed, err := canManagePanitia(ctx, c, kegiatan)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+		}
+		if !allowed {
+			return c.Status(403).JSON(fiber.Map{"error": "Only the assignee can update this tugas"})
+		}
+	}
+	update := bson.M{"$set": bson.M{"status": input.Status, "updated_by": utils.GetUserID(c), "updated_at": time.Now()}}
+	if _, err := config.DB.Collection("tugas").UpdateOne(ctx, filter, update); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update tugas"})
+	}
+	return c.JSON(fiber.Map{"message": "Tugas status updated", "status": input.Status})
+}
+
+// DeleteTugas godoc
+// @Summary Delete a task
+// @Tags Tugas
+// @Produce json
+// @Param id path string true "Kegiatan ID"
+// @Param tugasId path string true "Tugas ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /kegiatan/{id}/tugas/{tugasId} [delete]
+// @Security BearerAuth
+func DeleteTugas(c *fiber.Ctx) error {
+	tugasID, err := primitive.ObjectIDFromHex(c.Params("tugasId"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid tugas ID"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatan, err := findKegiatanByID(ctx, c.Params("id"))
+	if err != nil {
+		return kegiatanErrorResponse(c, err)
+	}
+	allowed, err := canManagePanitia(ctx, c, kegiatan)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Only UKM admins or ketua pelaksana can manage tugas"})
+	}
+	res, err := config.DB.Collection("tugas").DeleteOne(ctx, bson.M{"_id": tugasID, "kegiatan_id": kegiatan.ID})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete tugas"})
+	}
+	if res.DeletedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "Tugas not found"})
+	}
+	return c.JSON(fiber.Map{"message": "Tugas deleted"})
+}
+
+// GetMyTugas godoc
+// @Summary Get tasks assigned to the logged-in user
+// @Tags Tugas
+// @Produce json
+// @Param status query string false "Filter status (todo, in_progress, done)"
+// @Success 200 {array} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /me/tugas [get]
+// @Security BearerAuth
+func GetMyTugas(c *fiber.Ctx) error {
+	userID, err := currentUserObjectID(c)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	match := bson.M{"assignee_id": userID}
+	if status := c.Query("status"); status != "" {
+		match["status"] = status
+	}
+	pipeline := []bson.M{
+		{"$match": match},
+		{
+			"$lookup": bson.M{
+				"from":         "kegiatan",
+				"localField":   "kegiatan_id",
+				"foreignField": "_id",
+				"as":           "kegiatan_data",
+			},
+		},
+		{"$unwind": "$kegiatan_data"},
+		{"$match": bson.M{"kegiatan_data.deleted_at": nil}},
+		{
+			"$project": bson.M{
+				"_id":            1,
+				"kegiatan_id":    1,
+				"judul":          1,
+				"deskripsi":      1,
+				"due_date":       1,
+				"status":         1,
+				"kegiatan_judul": "$kegiatan_data.judul",
+				"kegiatan_ukm":   "$kegiatan_data.kategori",
+			},
+		},
+		{"$sort": bson.D{{Key: "due_date", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+	cursor, err := config.DB.Collection("tugas").Aggregate(ctx, pipeline)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch tugas"})
+	}
+	tugas := []bson.M{}
+	if err := cursor.All(ctx, &tugas); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode tugas"})
+	}
+	return c.JSON(tugas)
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// pemilikUpload mencari kegiatan yang memiliki file upload berdasarkan URL-nya.
+// Untuk dokumen izin, user pemilik kehadiran juga dikembalikan.
+func pemilikUpload(ctx context.Context, folder, url string) (primitive.ObjectID, primitive.ObjectID, error) {
+	var doc struct {
+		KegiatanID primitive.ObjectID `bson:"kegiatan_id"`
+		UserID     primitive.ObjectID `bson:"user_id"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"kegiatan_id": 1, "user_id": 1})
+	var err error
+	switch folder {
+	case "lpj":
+		err = config.DB.Collection("lpj").FindOne(ctx, bson.M{"lampiran.url": url}, opts).Decode(&doc)
+	case "anggaran":
+		err = config.DB.Collection("anggaran").FindOne(ctx, bson.M{"bukti_url": url}, opts).Decode(&doc)
+	case "izin":
+		err = config.DB.Collection("kehadiran").FindOne(ctx, bson.M{"dokumen_url": url}, opts).Decode(&doc)
+	default:
+		err = mongo.ErrNoDocuments
+	}
+	return doc.KegiatanID, doc.UserID, err
+}
+
+// DownloadUpload godoc
+// @Summary Download an uploaded file
+// @Description Lampiran LPJ dan bukti anggaran hanya dapat diunduh reviewer dan admin UKM penyelenggara kegiatan.
+// @Description Dokumen izin/sakit hanya dapat diunduh pengaju dan admin UKM penyelenggara kegiatan.
+// @Tags Upload
+// @Produce octet-stream
+// @Param folder path string true "Folder upload (lpj, anggaran, izin)"
+// @Param name path string true "Nama file"
+// @Success 200 {file} file
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /uploads/{folder}/{name} [get]
+// @Security BearerAuth
+func DownloadUpload(c *fiber.Ctx) error {
+	folder, name := c.Params("folder"), c.Params("name")
+	// Nama file selalu buatan SaveUpload, tolak path yang keluar dari folder upload
+	if name != filepath.Base(name) || folder != filepath.Base(folder) {
+		return c.Status(404).JSON(fiber.Map{"error": "File not found"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	kegiatanID, pemilikID, err := pemilikUpload(ctx, folder, "/uploads/"+folder+"/"+name)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "File not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch file"})
+	}
+	kegiatan, err := findKegiatanByID(ctx, kegiatanID.Hex())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "File not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch kegiatan"})
+	}
+	var allowed bool
+	if folder == "izin" {
+		// Dokumen izin (mis. surat dokter) tidak dibuka untuk reviewer
+		allowed = pemilikID.Hex() == utils.GetUserID(c)
+	} else {
+		allowed = utils.GetUserRole(c) == "reviewer"
+	}
+	if !allowed {
+		allowed, err = canManageKegiatan(ctx, c, kegiatan)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to check permission"})
+		}
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "You are not allowed to download this file"})
+	}
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	return c.SendFile(filepath.Join(utils.UploadDir(), folder, name))
+}
@@ -1,212 +1,311 @@
-package controller
-
-import (
-	"context"
-	"time"
-
-	"backend-sisteminformasi/config"
-	"backend-sisteminformasi/model"
-	"backend-sisteminformasi/utils"
-
-	"github.com/go-playground/validator/v10"
-	"github.com/gofiber/fiber/v2"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-)
-
-var userValidate = validator.New()
-
-// @Security BearerAuth
-// GetUsers godoc
-// @Summary Get all users
-// @Tags Users
-// @Produce json
-// @Success 200 {array} model.User
-// @Failure 500 {object} map[string]interface{}
-// @Router /users [get]
-func GetUsers(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	cursor, err := config.DB.Collection("users").Find(ctx, bson.M{})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch users"})
-	}
-
-	var users []model.User
-	if err := cursor.All(ctx, &users); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode users"})
-	}
-
-	// Hapus password dari response untuk keamanan
-	for i := range users {
-		users[i].Password = ""
-	}
-
-	return c.JSON(users)
-}
-
-// @Security BearerAuth
-// GetUser godoc
-// @Summary Get user by ID
-// @Tags Users
-// @Produce json
-// @Param id path string true "User ID"
-// @Success 200 {object} model.User
-// @Failure 400 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /users/{id} [get]
-func GetUser(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	var user model.User
-	err = config.DB.Collection("users").FindOne(ctx, bson.M{"_id": objID}).Decode(&user)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return c.Status(404).JSON(fiber.Map{"error": "User not found"})
-		}
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch user"})
-	}
-
-	// Hapus password dari response untuk keamanan
-	user.Password = ""
-
-	return c.JSON(user)
-}
-
-// @Security BearerAuth
-// CreateUser godoc
-// @Summary Create user
-// @Tags Users
-// @Accept json
-// @Produce json
-// @Param user body model.User true "User Data"
-// @Success 201 {object} model.User
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /users [post]
-func CreateUser(c *fiber.Ctx) error {
-	var user model.User
-	if err := c.BodyParser(&user); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
-	}
-
-	if err := userValidate.Struct(user); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Check if email already exists
-	var existingUser model.User
-	err := config.DB.Collection("users").FindOne(ctx, bson.M{"email": user.Email}).Decode(&existingUser)
-	if err == nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Email already exists"})
-	}
-
-	// Hash password before saving
-	hashedPassword, err := utils.HashPassword(user.Password)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to hash password"})
-	}
-	user.Password = hashedPassword
-
-	res, err := config.DB.Collection("users").InsertOne(ctx, user)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to create user"})
-	}
-
-	user.ID = res.InsertedID.(primitive.ObjectID).Hex()
-	user.Password = "" // Hapus password dari response
-
-	return c.Status(201).JSON(user)
-}
-
-// @Security BearerAuth
-// UpdateUser godoc
-// @Summary Update user
-// @Tags Users
-// @Accept json
-// @Produce json
-// @Param id path string true "User ID"
-// @Param user body model.User true "User Data"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /users/{id} [put]
-func UpdateUser(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-
-	var user model.User
-	if err := c.BodyParser(&user); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	update := bson.M{
-		"nama":  user.Nama,
-		"email": user.Email,
-		"role":  user.Role,
-		"ukm":   user.UKM,
-	}
-
-	// Hanya update password jika diberikan
-	if user.Password != "" {
-		hashedPassword, err := utils.HashPassword(user.Password)
-		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": "Failed to hash password"})
-		}
-		update["password"] = hashedPassword
-	}
-
-	_, err = config.DB.Collection("users").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": update})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to update user"})
-	}
-
-	return c.JSON(fiber.Map{"message": "User updated successfully"})
-}
-
-// @Security BearerAuth
-// DeleteUser godoc
-// @Summary Delete user
-// @Tags Users
-// @Produce json
-// @Param id path string true "User ID"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
-// @Router /users/{id} [delete]
-func DeleteUser(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	_, err = config.DB.Collection("users").DeleteOne(ctx, bson.M{"_id": objID})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete user"})
-	}
-
-	return c.JSON(fiber.Map{"message": "User deleted successfully"})
-}
+package controller
+
+import (
+	"context"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+	"backend-sisteminformasi/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var userValidate = validator.New()
+
+// @Security BearerAuth
+// GetUsers godoc
+// @Summary Get all users
+// @Tags Users
+// @Produce json
+// @Success 200 {array} model.User
+// @Failure 500 {object} map[string]interface{}
+// @Router /users [get]
+func GetUsers(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.DB.Collection("users").Find(ctx, notDeleted(bson.M{}))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch users"})
+	}
+
+	var users []model.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to decode users"})
+	}
+
+	// Hapus password dari response untuk keamanan
+	for i := range users {
+		users[i].Password = ""
+	}
+
+	return c.JSON(users)
+}
+
+// @Security BearerAuth
+// GetUser godoc
+// @Summary Get user by ID
+// @Tags Users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} model.User
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users/{id} [get]
+func GetUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user model.User
+	err = config.DB.Collection("users").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "User not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch user"})
+	}
+
+	// Hapus password dari response untuk keamanan
+	user.Password = ""
+
+	return c.JSON(user)
+}
+
+// @Security BearerAuth
+// CreateUser godoc
+// @Summary Create user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param user body model.User true "User Data"
+// @Success 201 {object} model.User
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users [post]
+func CreateUser(c *fiber.Ctx) error {
+	var user model.User
+	if err := c.BodyParser(&user); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if err := userValidate.Struct(user); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Check if email already exists
+	var existingUser model.User
+	err := config.DB.Collection("users").FindOne(ctx, bson.M{"email": user.Email}).Decode(&existingUser)
+	if err == nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Email already exists"})
+	}
+
+	// Hash password before saving
+	hashedPassword, err := utils.HashPassword(user.Password)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to hash password"})
+	}
+	user.Password = hashedPassword
+
+	now := time.Now()
+	user.CreatedBy = utils.GetUserID(c)
+	user.CreatedAt = now
+	user.UpdatedBy = user.CreatedBy
+	user.UpdatedAt = now
+	user.DeletedAt, user.DeletedBy = nil, ""
+
+	res, err := config.DB.Collection("users").InsertOne(ctx, user)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create user"})
+	}
+
+	user.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	user.Password = "" // Hapus password dari response
+
+	return c.Status(201).JSON(user)
+}
+
+// @Security BearerAuth
+// UpdateUser godoc
+// @Summary Update user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param user body model.User true "User Data"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users/{id} [put]
+func UpdateUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var user model.User
+	if err := c.BodyParser(&user); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"nama":       user.Nama,
+		"email":      user.Email,
+		"role":       user.Role,
+		"ukm":        user.UKM,
+		"updated_by": utils.GetUserID(c),
+		"updated_at": time.Now(),
+	}
+
+	// Hanya update password jika diberikan
+	if user.Password != "" {
+		hashedPassword, err := utils.HashPassword(user.Password)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to hash password"})
+		}
+		update["password"] = hashedPassword
+	}
+
+	_, err = config.DB.Collection("users").UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), bson.M{"$set": update})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update user"})
+	}
+
+	return c.JSON(fiber.Map{"message": "User updated successfully"})
+}
+
+// @Security BearerAuth
+// PatchUser godoc
+// @Summary Partially update user
+// @Description Hanya field yang dikirim yang diubah (JSON Merge Patch). Password baru akan di-hash.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param user body model.User true "Field user yang diubah"
+// @Success 200 {object} model.User
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users/{id} [patch]
+func PatchUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user model.User
+	err = config.DB.Collection("users").FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return c.Status(404).JSON(fiber.Map{"error": "User not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch user"})
+	}
+
+	update, err := applyMergePatch(c.Body(), &user)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := userValidate.Struct(user); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Email baru tidak boleh dipakai user lain
+	if _, ok := update["email"]; ok {
+		count, err := config.DB.Collection("users").CountDocuments(ctx, bson.M{"email": user.Email, "_id": bson.M{"$ne": objID}})
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to validate email"})
+		}
+		if count > 0 {
+			return c.Status(400).JSON(fiber.Map{"error": "Email already exists"})
+		}
+	}
+
+	// Password dari patch masih plain text, hash sebelum disimpan
+	if _, ok := update["password"]; ok {
+		hashedPassword, err := utils.HashPassword(user.Password)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to hash password"})
+		}
+		update["password"] = hashedPassword
+	}
+
+	user.ID = id
+	user.UpdatedBy = utils.GetUserID(c)
+	user.UpdatedAt = time.Now()
+	update["updated_by"] = user.UpdatedBy
+	update["updated_at"] = user.UpdatedAt
+
+	res, err := config.DB.Collection("users").UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), bson.M{"$set": update})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update user"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	// Hapus password dari response untuk keamanan
+	user.Password = ""
+
+	return c.JSON(user)
+}
+
+// @Security BearerAuth
+// DeleteUser godoc
+// @Summary Delete user (soft delete)
+// @Tags Users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /users/{id} [delete]
+func DeleteUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Soft delete: data dipindah ke tempat sampah dan masih bisa di-restore
+	update := bson.M{"$set": bson.M{
+		"deleted_at": time.Now(),
+		"deleted_by": utils.GetUserID(c),
+	}}
+	res, err := config.DB.Collection("users").UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), update)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete user"})
+	}
+	if res.MatchedCount == 0 {
+		return c.Status(404).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	return c.JSON(fiber.Map{"message": "User deleted successfully"})
+}
@@ -15,7 +15,7 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/kategori": {
+        "/anggaran/ukm": {
             "get": {
                 "security": [
                     {
@@ -26,16 +26,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kategori"
+                    "Anggaran"
+                ],
+                "summary": "Budget summary per UKM",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter nama UKM",
+                        "name": "ukm",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all kategori",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Kategori"
+                                "$ref": "#/definitions/controller.RingkasanAnggaranUKM"
                             }
                         }
                     },
@@ -47,43 +55,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/certificates/{serial}/verify": {
+            "get": {
+                "description": "Endpoint publik yang dituju QR code pada sertifikat.\nvalid=false jika kegiatan sudah dihapus atau tidak lagi approved, atau kehadiran peserta sudah dihapus atau diubah menjadi tidak hadir.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Kategori"
+                    "Sertifikat"
                 ],
-                "summary": "Create kategori",
+                "summary": "Verify certificate authenticity",
                 "parameters": [
                     {
-                        "description": "Kategori Data",
-                        "name": "kategori",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Kategori"
-                        }
+                        "type": "string",
+                        "description": "Nomor seri sertifikat",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Kategori"
+                            "$ref": "#/definitions/controller.VerifikasiSertifikatResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -99,7 +100,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/kategori/{id}": {
+        "/feedback/ukm": {
             "get": {
                 "security": [
                     {
@@ -110,13 +111,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kategori"
+                    "Feedback"
                 ],
-                "summary": "Get kategori by ID",
+                "summary": "Get aggregated ratings per UKM",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kategori ID",
+                        "description": "Filter UKM",
+                        "name": "ukm",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.RingkasanFeedbackUKM"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/import/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya dapat dilihat admin yang mengimport dan admin lain dari UKM yang sama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Get import progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -126,7 +170,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Kategori"
+                            "$ref": "#/definitions/model.ImportJob"
                         }
                     },
                     "400": {
@@ -151,47 +195,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/import/{jenis}": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Baris pertama file adalah header dengan nama field JSON model.\nkegiatan: judul, deskripsi, tanggal, lokasi, kategori, maxParticipants, dokumentasi_url. Kategori harus UKM admin yang mengimport.\nusers: nama, email, password, role (default member), ukm.\nkehadiran: user_id atau email, kegiatan_id, status, waktu_cek. Kegiatan harus approved dan diselenggarakan UKM admin,\nwaktu_cek hadir/terlambat harus berada di jendela check-in kegiatan.\nDengan dry_run=true hanya laporan validasi yang dikembalikan. Tanpa dry_run, baris valid disimpan\ndi background dan progres dapat dicek di /import/{id}.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Kategori"
+                    "Import"
                 ],
-                "summary": "Update kategori",
+                "summary": "Import kegiatan, users or kehadiran from CSV/XLSX",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kategori ID",
-                        "name": "id",
+                        "description": "Jenis data (kegiatan, users, kehadiran)",
+                        "name": "jenis",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Kategori Data",
-                        "name": "kategori",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Kategori"
-                        }
+                        "type": "boolean",
+                        "description": "Hanya validasi tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "File CSV atau XLSX",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/controller.ImportReport"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ImportReport"
                         }
                     },
                     "400": {
@@ -209,42 +265,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/jobs": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Menampilkan 100 job terbaru untuk pemantauan scheduler",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Kategori"
+                    "Jobs"
                 ],
-                "summary": "Delete kategori",
+                "summary": "Get background jobs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kategori ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter status (pending, running, done, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter tipe job",
+                        "name": "tipe",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Job"
+                            }
                         }
                     },
                     "500": {
@@ -257,7 +316,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/kegiatan": {
+        "/kategori": {
             "get": {
                 "security": [
                     {
@@ -268,16 +327,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kegiatan"
+                    "Kategori"
                 ],
-                "summary": "Get all kegiatan",
+                "summary": "Get all kategori",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Kegiatan"
+                                "$ref": "#/definitions/model.Kategori"
                             }
                         }
                     },
@@ -303,17 +362,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kegiatan"
+                    "Kategori"
                 ],
-                "summary": "Create kegiatan",
+                "summary": "Create kategori",
                 "parameters": [
                     {
-                        "description": "Kegiatan Data",
-                        "name": "kegiatan",
+                        "description": "Kategori Data",
+                        "name": "kategori",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Kegiatan"
+                            "$ref": "#/definitions/model.Kategori"
                         }
                     }
                 ],
@@ -321,7 +380,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Kegiatan"
+                            "$ref": "#/definitions/model.Kategori"
                         }
                     },
                     "400": {
@@ -341,7 +400,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/kegiatan/{id}": {
+        "/kategori/{id}": {
             "get": {
                 "security": [
                     {
@@ -352,13 +411,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kegiatan"
+                    "Kategori"
                 ],
-                "summary": "Get kegiatan by ID",
+                "summary": "Get kategori by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kegiatan ID",
+                        "description": "Kategori ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -368,7 +427,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Kegiatan"
+                            "$ref": "#/definitions/model.Kategori"
                         }
                     },
                     "400": {
@@ -407,24 +466,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kegiatan"
+                    "Kategori"
                 ],
-                "summary": "Update kegiatan",
+                "summary": "Update kategori",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kegiatan ID",
+                        "description": "Kategori ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Kegiatan Data",
-                        "name": "kegiatan",
+                        "description": "Kategori Data",
+                        "name": "kategori",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Kegiatan"
+                            "$ref": "#/definitions/model.Kategori"
                         }
                     }
                 ],
@@ -462,13 +521,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kegiatan"
+                    "Kategori"
                 ],
-                "summary": "Delete kegiatan",
+                "summary": "Delete kategori (soft delete)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kegiatan ID",
+                        "description": "Kategori ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -489,39 +548,12 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/kehadiran": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Kehadiran"
-                ],
-                "summary": "Get all kehadiran",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Kehadiran"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -532,12 +564,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Hanya field yang dikirim yang diubah (JSON Merge Patch), field bernilai null dikosongkan",
                 "consumes": [
                     "application/json"
                 ],
@@ -545,25 +578,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kehadiran"
+                    "Kategori"
                 ],
-                "summary": "Create kehadiran",
+                "summary": "Partially update kategori",
                 "parameters": [
                     {
-                        "description": "Kehadiran Data",
-                        "name": "kehadiran",
+                        "type": "string",
+                        "description": "Kategori ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field kategori yang diubah",
+                        "name": "kategori",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Kehadiran"
+                            "$ref": "#/definitions/model.Kategori"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Kehadiran"
+                            "$ref": "#/definitions/model.Kategori"
                         }
                     },
                     "400": {
@@ -573,6 +613,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -583,8 +630,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/kehadiran/{id}": {
-            "get": {
+        "/kategori/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -594,13 +641,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Kehadiran"
+                    "Trash"
                 ],
-                "summary": "Get kehadiran by ID",
+                "summary": "Restore deleted kategori",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kehadiran ID",
+                        "description": "Kategori ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -610,7 +657,8 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Kehadiran"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -635,54 +683,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/kegiatan": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Member hanya melihat kegiatan yang sudah approved. Admin dan reviewer dapat memfilter dengan approval_status.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Kehadiran"
+                    "Kegiatan"
                 ],
-                "summary": "Update kehadiran",
+                "summary": "Get all kegiatan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kehadiran ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Kehadiran Data",
-                        "name": "kehadiran",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Kehadiran"
-                        }
+                        "description": "Filter status pengajuan (draft, submitted, approved, rejected)",
+                        "name": "approval_status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Kegiatan"
+                            }
                         }
                     },
                     "500": {
@@ -694,34 +727,39 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Kegiatan baru disimpan sebagai draft dan perlu di-submit untuk direview",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Kehadiran"
+                    "Kegiatan"
                 ],
-                "summary": "Delete kehadiran",
+                "summary": "Create kegiatan",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Kehadiran ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Kegiatan Data",
+                        "name": "kegiatan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Kegiatan"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Kegiatan"
                         }
                     },
                     "400": {
@@ -741,38 +779,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "consumes": [
-                    "application/json"
+        "/kegiatan/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Kegiatan"
                 ],
-                "summary": "Login user",
+                "summary": "Get kegiatan by ID",
                 "parameters": [
                     {
-                        "description": "Login Data",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Kegiatan"
                         }
                     },
                     "400": {
@@ -782,8 +816,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -797,10 +831,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/register": {
-            "post": {
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin UKM co-host dapat mengubah detail kegiatan, tetapi kategori dan co_hosts hanya dapat diubah admin UKM tuan rumah\nPerubahan isi (judul, deskripsi, jadwal, lokasi, kuota, publik, penyelenggara) ditolak selama kegiatan direview dan mengembalikan kegiatan yang sudah disetujui ke draft",
                 "consumes": [
                     "application/json"
                 ],
@@ -808,23 +846,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Kegiatan"
                 ],
-                "summary": "Register user",
+                "summary": "Update kegiatan",
                 "parameters": [
                     {
-                        "description": "User Data",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kegiatan Data",
+                        "name": "kegiatan",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.Kegiatan"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -837,35 +882,25 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/statistics": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Statistics"
-                ],
-                "summary": "Get statistics data",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/controller.StatisticsResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -876,30 +911,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Hanya admin UKM tuan rumah yang dapat menghapus, co-host tidak",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Kegiatan"
+                ],
+                "summary": "Delete kegiatan (soft delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.User"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -911,12 +973,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Hanya field yang dikirim yang diubah (JSON Merge Patch), field bernilai null dikosongkan. Aturan hak akses co-host dan status pengajuan sama dengan PUT.",
                 "consumes": [
                     "application/json"
                 ],
@@ -924,25 +987,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Kegiatan"
                 ],
-                "summary": "Create user",
+                "summary": "Partially update kegiatan",
                 "parameters": [
                     {
-                        "description": "User Data",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field kegiatan yang diubah",
+                        "name": "kegiatan",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.Kegiatan"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/controller.KegiatanResponse"
                         }
                     },
                     "400": {
@@ -952,6 +1022,27 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -962,24 +1053,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/kegiatan/{id}/anggaran": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Daftar rencana, pengeluaran dan pemasukan beserta perhitungan varians",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Anggaran"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Get budget of a kegiatan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Kegiatan ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -989,7 +1081,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/controller.AnggaranResponse"
                         }
                     },
                     "400": {
@@ -1015,12 +1107,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Hanya admin UKM tuan rumah utama yang dapat menambah anggaran, co-host hanya dapat melihat",
                 "consumes": [
                     "application/json"
                 ],
@@ -1028,24 +1121,104 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Anggaran"
                 ],
-                "summary": "Update user",
+                "summary": "Add a budget line to a kegiatan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Kegiatan ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User Data",
-                        "name": "user",
+                        "description": "Item anggaran",
+                        "name": "anggaran",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.AnggaranItem"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.AnggaranItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/anggaran/{itemId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anggaran"
+                ],
+                "summary": "Update a budget line",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Anggaran item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item anggaran",
+                        "name": "anggaran",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AnggaranItem"
                         }
                     }
                 ],
@@ -1064,6 +1237,20 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1083,16 +1270,102 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Anggaran"
                 ],
-                "summary": "Delete user",
+                "summary": "Delete a budget line",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Anggaran item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/anggaran/{itemId}/bukti": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Anggaran"
+                ],
+                "summary": "Upload a receipt for a budget line",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Anggaran item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Bukti/kuitansi (pdf atau gambar, maks 10MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1110,6 +1383,107 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/checkin": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kehadiran dicatat untuk user yang login, hanya jika kode QR masih berlaku untuk kegiatan tersebut.\nJika kegiatan memiliki koordinat, lokasi_cek wajib dikirim dan check-in di luar radius ditandai untuk diverifikasi admin.\nCheck-in hanya diterima dalam jendela check-in kegiatan, setelah jam mulai + toleransi status menjadi terlambat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Check-in"
+                ],
+                "summary": "Check in to a kegiatan by scanning its QR code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kode hasil scan QR",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CheckinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1119,123 +1493,6885 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controller.ActivityStats": {
+        },
+        "/kegiatan/{id}/checkin-qr": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ditampilkan panitia di lokasi. Kode berganti setiap CHECKIN_QR_INTERVAL detik, gunakan format=png untuk gambar QR.",
+                "produces": [
+                    "application/json",
+                    "image/png"
+                ],
+                "tags": [
+                    "Check-in"
+                ],
+                "summary": "Get the rotating check-in QR code of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Isi png untuk mendapatkan gambar QR",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.CheckinQRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/duplicate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menyalin deskripsi, lokasi, kategori, kapasitas, panitia, dan rencana anggaran ke kegiatan draft baru dengan tanggal baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Kegiatan"
+                ],
+                "summary": "Duplicate a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tanggal dan judul baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.DuplikatKegiatanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controller.KegiatanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/feedback": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Identitas pengirim feedback anonim tidak ditampilkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedback"
+                ],
+                "summary": "Get feedback of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FeedbackListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya anggota yang tercatat hadir pada kegiatan yang dapat memberi penilaian, satu kali per kegiatan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedback"
+                ],
+                "summary": "Submit feedback for a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating 1-5 dan komentar",
+                        "name": "feedback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.FeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controller.FeedbackResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/feedback/ringkasan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedback"
+                ],
+                "summary": "Get aggregated rating of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.RingkasanFeedback"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/izin": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dapat diajukan sebelum kegiatan atau setelahnya (maks. 7 hari setelah selesai), termasuk untuk mengganti status alpa.\nDokumen pendukung (mis. surat dokter) opsional. Pengajuan menunggu persetujuan admin lewat PUT /kehadiran/{id}/verifikasi.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Submit an excuse (izin/sakit) for a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "izin atau sakit",
+                        "name": "status",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alasan tidak hadir",
+                        "name": "alasan",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Dokumen pendukung",
+                        "name": "dokumen",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/kehadiran/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencatat atau memperbarui kehadiran banyak user sekaligus dalam satu operasi. Jika jendela check-in sudah\nditutup, peserta terdaftar yang tidak ada di daftar dan belum memiliki kehadiran otomatis dicatat alpa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Mark kehadiran of many members at once",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Daftar user dan status kehadiran",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.BulkKehadiranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.BulkKehadiranResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/kehadiran/sync": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunggah check-in yang dicatat aplikasi panitia saat offline. Setiap data wajib memiliki client_id unik buatan aplikasi\nsehingga unggahan ulang aman (hasil duplicate). Jika server sudah memiliki status berbeda untuk user tersebut, data tidak\nditimpa dan dilaporkan sebagai conflict beserta status_server. waktu_cek harus berada di jendela check-in kegiatan,\nstatus hadir/terlambat dihitung server dari waktu_cek seperti check-in mandiri.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Sync check-ins captured offline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Check-in offline dengan waktu_cek asli (RFC3339)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.SyncKehadiranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.SyncKehadiranResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/lpj": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya reviewer dan admin UKM penyelenggara kegiatan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LPJ"
+                ],
+                "summary": "Get LPJ of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LPJ"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LPJ"
+                ],
+                "summary": "Update LPJ narrative and budget realization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "LPJ Data",
+                        "name": "lpj",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.LPJRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LPJ"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ringkasan kehadiran diambil otomatis dari data kehadiran kegiatan. Jika realisasi_anggaran kosong, diisi dari anggaran kegiatan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LPJ"
+                ],
+                "summary": "Create LPJ for a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "LPJ Data",
+                        "name": "lpj",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.LPJRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.LPJ"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/lpj/lampiran": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LPJ"
+                ],
+                "summary": "Upload an attachment to the LPJ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File lampiran (pdf, gambar, dokumen office, maks 10MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keterangan lampiran",
+                        "name": "keterangan",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.LampiranLPJ"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/lpj/lampiran/{lampiranId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LPJ"
+                ],
+                "summary": "Remove an attachment from the LPJ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lampiran ID",
+                        "name": "lampiranId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/lpj/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LPJ"
+                ],
+                "summary": "Approve an LPJ or request revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Keputusan reviewer",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ReviewLPJRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LPJ"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/lpj/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ringkasan kehadiran dibekukan saat submit dan reviewer menerima notifikasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LPJ"
+                ],
+                "summary": "Submit LPJ for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LPJ"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/panitia": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "user_email hanya ditampilkan untuk admin UKM penyelenggara dan ketua pelaksana",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Panitia"
+                ],
+                "summary": "Get committee of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Peran: ketua_pelaksana (hanya satu per kegiatan), sekretaris, bendahara, divisi (wajib isi divisi)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Panitia"
+                ],
+                "summary": "Add a committee member to a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Anggota panitia",
+                        "name": "panitia",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Panitia"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Panitia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/panitia/{panitiaId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Panitia"
+                ],
+                "summary": "Change the role of a committee member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Panitia ID",
+                        "name": "panitiaId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Peran baru",
+                        "name": "panitia",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.UpdatePanitiaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tugas yang masih ditugaskan ke anggota tersebut menjadi tanpa penanggung jawab",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Panitia"
+                ],
+                "summary": "Remove a committee member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Panitia ID",
+                        "name": "panitiaId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore deleted kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Komentar wajib diisi saat menolak. Pembuat kegiatan akan menerima notifikasi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kegiatan Approval"
+                ],
+                "summary": "Approve or reject a submitted kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Keputusan reviewer",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.ReviewKegiatanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.KegiatanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/sertifikat": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sertifikat"
+                ],
+                "summary": "Get issued certificates of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Sertifikat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/sertifikat/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menerbitkan sertifikat untuk setiap kehadiran berstatus hadir. Sertifikat yang sudah ada tidak diterbitkan ulang.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sertifikat"
+                ],
+                "summary": "Issue certificates for all attendees of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.GenerateSertifikatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/sertifikat/me": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Anggota yang tercatat hadir dapat menerbitkan sertifikatnya sendiri setelah template diatur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sertifikat"
+                ],
+                "summary": "Issue the certificate of the logged-in user for a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Sertifikat"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/sertifikat/template": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sertifikat"
+                ],
+                "summary": "Get certificate template of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TemplateSertifikat"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Judul (mis. \"Sertifikat Partisipasi\"), keterangan sebelum nama kegiatan, dan 1-3 penandatangan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sertifikat"
+                ],
+                "summary": "Create or update certificate template of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template sertifikat",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.TemplateSertifikatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TemplateSertifikat"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah status draft/rejected menjadi submitted dan memberi notifikasi ke reviewer. Hanya admin UKM penyelenggara kegiatan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kegiatan Approval"
+                ],
+                "summary": "Submit kegiatan proposal for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.KegiatanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/tamu": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya reviewer dan admin UKM penyelenggara kegiatan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tamu"
+                ],
+                "summary": "Get guests of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status kehadiran (hadir, tidak)",
+                        "name": "status_kehadiran",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Tamu"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/tamu/{tamuId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tamu"
+                ],
+                "summary": "Remove a guest registration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tamu ID",
+                        "name": "tamuId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/tamu/{tamuId}/convert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat akun member dari data tamu. Semua kehadiran tamu dengan email yang sama dipindahkan ke kehadiran member.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tamu"
+                ],
+                "summary": "Convert a guest into a member account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tamu ID",
+                        "name": "tamuId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Password awal dan UKM",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.KonversiTamuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/tamu/{tamuId}/kehadiran": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tamu"
+                ],
+                "summary": "Record guest attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tamu ID",
+                        "name": "tamuId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status kehadiran",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.KehadiranTamuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/template": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Kegiatan"
+                ],
+                "summary": "Save a kegiatan to the UKM template library",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nama template",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.SimpanTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.TemplateKegiatan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/tugas": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tugas"
+                ],
+                "summary": "Get tasks of a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status (todo, in_progress, done)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Tugas"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Penanggung jawab (assignee_id) harus terdaftar sebagai panitia kegiatan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tugas"
+                ],
+                "summary": "Create a task for a kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tugas",
+                        "name": "tugas",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Tugas"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tugas"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/tugas/{tugasId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tugas"
+                ],
+                "summary": "Update a task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tugas ID",
+                        "name": "tugasId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tugas",
+                        "name": "tugas",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Tugas"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tugas"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tugas"
+                ],
+                "summary": "Delete a task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tugas ID",
+                        "name": "tugasId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kegiatan/{id}/tugas/{tugasId}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dapat dilakukan oleh penanggung jawab tugas, admin UKM, atau ketua pelaksana",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tugas"
+                ],
+                "summary": "Update task status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tugas ID",
+                        "name": "tugasId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status baru",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.UpdateStatusTugasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kehadiran": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Get all kehadiran",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Kehadiran"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Non-admin hanya dapat mencatat kehadiran dirinya sendiri dengan token dari kode QR kegiatan: user_id diambil dari JWT,\nwaktu_cek dan status diisi server seperti POST /kegiatan/{id}/checkin.\nAdmin UKM penyelenggara mencatat kehadiran user lain dengan waktu_cek saat ini, hanya untuk kegiatan approved\ndan selama jendela check-in belum ditutup. Koreksi setelahnya memakai PATCH /kehadiran/{id}.\nSatu user hanya memiliki satu kehadiran per kegiatan: jika sudah ada, data lama dikembalikan dengan status 200.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Create kehadiran",
+                "parameters": [
+                    {
+                        "description": "Kehadiran Data",
+                        "name": "kehadiran",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.CreateKehadiranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kehadiran/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar user yang memiliki lebih dari satu kehadiran aktif pada kegiatan yang sama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Detect duplicate kehadiran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter kegiatan",
+                        "name": "kegiatan_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.KehadiranDuplikat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kehadiran/duplicates/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mempertahankan satu kehadiran per user per kegiatan (hadir \u003e terlambat \u003e izin/sakit \u003e alpa, lalu yang paling awal dibuat).\nSisanya dipindahkan ke trash, kemudian index unik kehadiran dibuat ulang.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Merge duplicate kehadiran",
+                "parameters": [
+                    {
+                        "description": "Batasi ke satu kegiatan",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controller.MergeKehadiranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.MergeKehadiranResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kehadiran/flagged": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kehadiran yang check-in di luar radius lokasi kegiatan dan pengajuan izin/sakit. Default hanya yang masih pending.\nAdmin hanya melihat kehadiran kegiatan yang diselenggarakan UKM-nya (termasuk co-host).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Get flagged kehadiran for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status verifikasi (pending, approved, rejected)",
+                        "name": "verifikasi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter jenis (lokasi, izin)",
+                        "name": "jenis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kegiatan",
+                        "name": "kegiatan_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Kehadiran"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kehadiran/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Get kehadiran by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kehadiran ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Update kehadiran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kehadiran ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kehadiran Data",
+                        "name": "kehadiran",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Delete kehadiran (soft delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kehadiran ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya field yang dikirim yang diubah (JSON Merge Patch), field bernilai null dikosongkan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Partially update kehadiran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kehadiran ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field kehadiran yang diubah",
+                        "name": "kehadiran",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kehadiran/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore deleted kehadiran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kehadiran ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/kehadiran/{id}/verifikasi": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Berlaku untuk check-in di luar radius maupun pengajuan izin/sakit. Kehadiran yang ditolak diubah menjadi alpa dan peserta menerima notifikasi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Approve or reject a flagged kehadiran",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kehadiran ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Keputusan verifikasi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.VerifikasiKehadiranRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Kehadiran"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login Data",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lpj/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kegiatan approved yang sudah melewati batas LPJ_DEADLINE_DAYS tetapi LPJ-nya belum di-submit atau disetujui.\nAdmin hanya melihat kegiatan yang diselenggarakan UKM-nya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LPJ"
+                ],
+                "summary": "List kegiatan with overdue LPJ",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.OverdueLPJ"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/kehadiran": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tingkat kehadiran per semester, jumlah per status, streak hadir berturut-turut, kegiatan yang terlewat (alpa), dan riwayat kehadiran.\nKegiatan approved yang sudah selesai dan wajib diikuti (anggota UKM penyelenggara atau panitia) tanpa catatan kehadiran dihitung alpa.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Get attendance recap of the logged-in user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.RekapKehadiranResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/sertifikat": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sertifikat"
+                ],
+                "summary": "Get certificates of the logged-in user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Sertifikat"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/tugas": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tugas"
+                ],
+                "summary": "Get tasks assigned to the logged-in user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status (todo, in_progress, done)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifikasi": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifikasi"
+                ],
+                "summary": "Get notifications of the logged in user",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Hanya notifikasi yang belum dibaca",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Notifikasi"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifikasi/read-all": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifikasi"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifikasi/{id}/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifikasi"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notifikasi ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/public/kegiatan": {
+            "get": {
+                "description": "Daftar kegiatan publik yang sudah disetujui untuk halaman depan kampus, tanpa login. Secara default hanya kegiatan mulai hari ini.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get public upcoming kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter UKM penyelenggara (termasuk co-host)",
+                        "name": "ukm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan kegiatan yang sudah lewat",
+                        "name": "semua",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah maksimal data (default 50, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/controller.PublicKegiatanResponse"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/public/kegiatan/{id}": {
+            "get": {
+                "description": "Kegiatan internal atau yang belum disetujui dianggap tidak ada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get public kegiatan by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.PublicKegiatanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/public/kegiatan/{id}/tamu": {
+            "post": {
+                "description": "Pendaftaran tanpa login untuk peserta dari luar UKM. Hanya untuk kegiatan publik yang sudah disetujui.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tamu"
+                ],
+                "summary": "Register as a guest for a public kegiatan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kegiatan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data tamu",
+                        "name": "tamu",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.DaftarTamuRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tamu"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register user",
+                "parameters": [
+                    {
+                        "description": "User Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/sertifikat/{serial}/pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya pemilik sertifikat, admin, atau reviewer yang dapat mengunduh",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Sertifikat"
+                ],
+                "summary": "Download certificate as PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nomor seri sertifikat",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Get statistics data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.StatisticsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/template-kegiatan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Kegiatan"
+                ],
+                "summary": "Get kegiatan templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter UKM",
+                        "name": "ukm",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TemplateKegiatan"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/template-kegiatan/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Kegiatan"
+                ],
+                "summary": "Get kegiatan template by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TemplateKegiatan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Kegiatan"
+                ],
+                "summary": "Delete a kegiatan template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/template-kegiatan/{id}/kegiatan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Template Kegiatan"
+                ],
+                "summary": "Create a draft kegiatan from a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tanggal dan judul kegiatan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.DuplikatKegiatanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/controller.KegiatanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan data yang sudah dihapus dan belum dibersihkan permanen. Tanpa parameter type, semua koleksi ditampilkan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Get soft-deleted data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Jenis data (kegiatan, kehadiran, kategori, users)",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{folder}/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lampiran LPJ dan bukti anggaran hanya dapat diunduh reviewer dan admin UKM penyelenggara kegiatan.\nDokumen izin/sakit hanya dapat diunduh pengaju dan admin UKM penyelenggara kegiatan.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Download an uploaded file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder upload (lpj, anggaran, izin)",
+                        "name": "folder",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nama file",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "User Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user (soft delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya field yang dikirim yang diubah (JSON Merge Patch). Password baru akan di-hash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Partially update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field user yang diubah",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/kehadiran": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hanya dapat dilihat oleh user itu sendiri dan admin UKM-nya. Perhitungan sama dengan GET /me/kehadiran.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kehadiran"
+                ],
+                "summary": "Get attendance recap of a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.RekapKehadiranResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore deleted user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controller.ActivityStats": {
+            "type": "object",
+            "properties": {
+                "attendees": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "guests": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "ukm": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.AnggaranResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AnggaranItem"
+                    }
+                },
+                "ringkasan": {
+                    "$ref": "#/definitions/controller.RingkasanAnggaran"
+                }
+            }
+        },
+        "controller.BulkKehadiranHasil": {
+            "type": "object",
+            "properties": {
+                "hasil": {
+                    "type": "string"
+                },
+                "pesan": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.BulkKehadiranItem": {
+            "type": "object",
+            "required": [
+                "status",
+                "user_id"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "hadir",
+                        "terlambat",
+                        "izin",
+                        "sakit",
+                        "alpa",
+                        "tidak"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.BulkKehadiranRequest": {
+            "type": "object",
+            "required": [
+                "kehadiran"
+            ],
+            "properties": {
+                "kehadiran": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/controller.BulkKehadiranItem"
+                    }
+                }
+            }
+        },
+        "controller.BulkKehadiranResponse": {
+            "type": "object",
+            "properties": {
+                "alpa_otomatis": {
+                    "type": "integer"
+                },
+                "dibuat": {
+                    "type": "integer"
+                },
+                "diperbarui": {
+                    "type": "integer"
+                },
+                "gagal": {
+                    "type": "integer"
+                },
+                "hasil": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.BulkKehadiranHasil"
+                    }
+                },
+                "tidak_berubah": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.CheckinQRResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "interval_detik": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CheckinRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "lokasi_cek": {
+                    "$ref": "#/definitions/model.LokasiCek"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.CreateKehadiranRequest": {
+            "type": "object",
+            "required": [
+                "kegiatan_id",
+                "status",
+                "user_id"
+            ],
+            "properties": {
+                "alasan": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "catatan_verifikasi": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
+                "dokumen_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "lokasi_cek": {
+                    "$ref": "#/definitions/model.LokasiCek"
+                },
+                "metode": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "hadir",
+                        "terlambat",
+                        "izin",
+                        "sakit",
+                        "alpa",
+                        "tidak"
+                    ]
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                },
+                "verifikasi": {
+                    "type": "string"
+                },
+                "waktu_cek": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.DaftarTamuRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "nama"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "institusi": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "nama": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "no_hp": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "controller.DuplikatKegiatanRequest": {
+            "type": "object",
+            "required": [
+                "tanggal"
+            ],
+            "properties": {
+                "judul": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.FeedbackListResponse": {
+            "type": "object",
+            "properties": {
+                "feedback": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.FeedbackResponse"
+                    }
+                },
+                "ringkasan": {
+                    "$ref": "#/definitions/controller.RingkasanFeedback"
+                }
+            }
+        },
+        "controller.FeedbackRequest": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "anonim": {
+                    "type": "boolean"
+                },
+                "komentar": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "controller.FeedbackResponse": {
+            "type": "object",
+            "properties": {
+                "anonim": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "komentar": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "user_nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.FeedbackStats": {
+            "type": "object",
+            "properties": {
+                "averageRating": {
+                    "type": "number"
+                },
+                "byUkm": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.UkmFeedbackStats"
+                    }
+                },
+                "ratingDistribution": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "totalFeedback": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.GenerateSertifikatResponse": {
+            "type": "object",
+            "properties": {
+                "diterbitkan": {
+                    "type": "integer"
+                },
+                "gagal": {
+                    "type": "integer"
+                },
+                "sudah_ada": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.ImportReport": {
+            "type": "object",
+            "properties": {
+                "baris_invalid": {
+                    "type": "integer"
+                },
+                "baris_valid": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportRowError"
+                    }
+                },
+                "import_id": {
+                    "type": "string"
+                },
+                "jenis": {
+                    "type": "string"
+                },
+                "total_baris": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.KegiatanResponse": {
+            "type": "object",
+            "properties": {
+                "approval_status": {
+                    "type": "string"
+                },
+                "co_hosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deskripsi": {
+                    "type": "string"
+                },
+                "dokumentasi_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jendela_checkin": {
+                    "$ref": "#/definitions/model.JendelaCheckin"
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "kategori": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "lokasi": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "maxParticipants": {
+                    "type": "integer"
+                },
+                "publik": {
+                    "type": "boolean"
+                },
+                "radius_meter": {
+                    "type": "integer"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.KegiatanReview"
+                    }
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "tanggal_selesai": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.KehadiranDuplikat": {
+            "type": "object",
+            "properties": {
+                "jumlah": {
+                    "type": "integer"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "kehadiran": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Kehadiran"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.KehadiranStats": {
+            "type": "object",
+            "properties": {
+                "attendanceRate": {
+                    "type": "number"
+                },
+                "excused": {
+                    "type": "integer"
+                },
+                "late": {
+                    "type": "integer"
+                },
+                "pendingReview": {
+                    "type": "integer"
+                },
+                "permission": {
+                    "type": "integer"
+                },
+                "present": {
+                    "type": "integer"
+                },
+                "sick": {
+                    "type": "integer"
+                },
+                "unexcused": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.KehadiranTamuRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "hadir",
+                        "tidak"
+                    ]
+                }
+            }
+        },
+        "controller.KonversiTamuRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "ukm"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "ukm": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.LPJRequest": {
+            "type": "object",
+            "required": [
+                "narasi"
+            ],
+            "properties": {
+                "narasi": {
+                    "type": "string"
+                },
+                "realisasi_anggaran": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RealisasiAnggaran"
+                    }
+                }
+            }
+        },
+        "controller.MemberStats": {
+            "type": "object",
+            "properties": {
+                "adminCount": {
+                    "type": "integer"
+                },
+                "memberCount": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "ukm": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.MergeKehadiranRequest": {
+            "type": "object",
+            "properties": {
+                "kegiatan_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.MergeKehadiranResponse": {
+            "type": "object",
+            "properties": {
+                "dihapus": {
+                    "type": "integer"
+                },
+                "disimpan": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "grup": {
+                    "type": "integer"
+                },
+                "index_aman": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "controller.OverdueLPJ": {
+            "type": "object",
+            "properties": {
+                "batas_lpj": {
+                    "type": "string"
+                },
+                "hari_terlambat": {
+                    "type": "integer"
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "kategori": {
+                    "type": "string"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "status_lpj": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.PublicKegiatanResponse": {
+            "type": "object",
+            "properties": {
+                "co_hosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deskripsi": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "lokasi": {
+                    "type": "string"
+                },
+                "maxParticipants": {
+                    "type": "integer"
+                },
+                "sisa_kuota": {
+                    "description": "SisaKuota kosong jika kegiatan tidak membatasi peserta",
+                    "type": "integer"
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "ukm": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.RekapKehadiranResponse": {
+            "type": "object",
+            "properties": {
+                "nama": {
+                    "type": "string"
+                },
+                "pending_review": {
+                    "type": "integer"
+                },
+                "per_semester": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.SemesterKehadiran"
+                    }
+                },
+                "per_status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "riwayat": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.RiwayatKehadiran"
+                    }
+                },
+                "streak_saat_ini": {
+                    "type": "integer"
+                },
+                "streak_terpanjang": {
+                    "type": "integer"
+                },
+                "terlewat": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.RiwayatKehadiran"
+                    }
+                },
+                "tingkat_kehadiran": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.ReviewKegiatanRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "komentar": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "rejected"
+                    ]
+                }
+            }
+        },
+        "controller.ReviewLPJRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "komentar": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "revision"
+                    ]
+                }
+            }
+        },
+        "controller.RingkasanAnggaran": {
+            "type": "object",
+            "properties": {
+                "per_kategori": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.VarianKategori"
+                    }
+                },
+                "persentase_realisasi": {
+                    "type": "number"
+                },
+                "saldo": {
+                    "type": "number"
+                },
+                "selisih": {
+                    "type": "number"
+                },
+                "total_pemasukan": {
+                    "type": "number"
+                },
+                "total_pengeluaran": {
+                    "type": "number"
+                },
+                "total_rencana": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.RingkasanAnggaranUKM": {
+            "type": "object",
+            "properties": {
+                "jumlah_kegiatan": {
+                    "type": "integer"
+                },
+                "saldo": {
+                    "type": "number"
+                },
+                "selisih": {
+                    "type": "number"
+                },
+                "total_pemasukan": {
+                    "type": "number"
+                },
+                "total_pengeluaran": {
+                    "type": "number"
+                },
+                "total_rencana": {
+                    "type": "number"
+                },
+                "ukm": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.RingkasanFeedback": {
+            "type": "object",
+            "properties": {
+                "distribusi": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "jumlah_feedback": {
+                    "type": "integer"
+                },
+                "rata_rata": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.RingkasanFeedbackUKM": {
+            "type": "object",
+            "properties": {
+                "distribusi": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "jumlah_feedback": {
+                    "type": "integer"
+                },
+                "jumlah_kegiatan": {
+                    "type": "integer"
+                },
+                "rata_rata": {
+                    "type": "number"
+                },
+                "ukm": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.RiwayatKehadiran": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "kegiatan_nama": {
+                    "type": "string"
+                },
+                "kegiatan_tanggal": {
+                    "type": "string"
+                },
+                "metode": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "ukm": {
+                    "type": "string"
+                },
+                "verifikasi": {
+                    "type": "string"
+                },
+                "waktu_cek": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.SemesterKehadiran": {
+            "type": "object",
+            "properties": {
+                "per_status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "tingkat_kehadiran": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.SimpanTemplateRequest": {
+            "type": "object",
+            "required": [
+                "nama"
+            ],
+            "properties": {
+                "nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.StatisticsResponse": {
+            "type": "object",
+            "properties": {
+                "feedback": {
+                    "$ref": "#/definitions/controller.FeedbackStats"
+                },
+                "kegiatanByStatus": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "kegiatanByUkm": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.UkmStats"
+                    }
+                },
+                "kehadiran": {
+                    "$ref": "#/definitions/controller.KehadiranStats"
+                },
+                "membersByUkm": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.MemberStats"
+                    }
+                },
+                "recentActivities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.ActivityStats"
+                    }
+                },
+                "totalAnggota": {
+                    "type": "integer"
+                },
+                "totalKegiatan": {
+                    "type": "integer"
+                },
+                "totalKehadiran": {
+                    "type": "integer"
+                },
+                "totalTamu": {
+                    "type": "integer"
+                },
+                "totalTamuHadir": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.SyncKehadiranHasil": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "hasil": {
+                    "type": "string"
+                },
+                "kehadiran_id": {
+                    "type": "string"
+                },
+                "pesan": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_server": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.SyncKehadiranItem": {
+            "type": "object",
+            "required": [
+                "client_id",
+                "user_id",
+                "waktu_cek"
+            ],
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "status": {
+                    "description": "Status dari perangkat hanya informasi, status tersimpan dihitung ulang dari waktu_cek",
+                    "type": "string",
+                    "enum": [
+                        "hadir",
+                        "terlambat"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "waktu_cek": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.SyncKehadiranRequest": {
+            "type": "object",
+            "required": [
+                "kehadiran"
+            ],
+            "properties": {
+                "kehadiran": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/controller.SyncKehadiranItem"
+                    }
+                }
+            }
+        },
+        "controller.SyncKehadiranResponse": {
+            "type": "object",
+            "properties": {
+                "diterima": {
+                    "type": "integer"
+                },
+                "duplikat": {
+                    "type": "integer"
+                },
+                "gagal": {
+                    "type": "integer"
+                },
+                "hasil": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.SyncKehadiranHasil"
+                    }
+                },
+                "konflik": {
+                    "type": "integer"
+                },
+                "tidak_berubah": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.TemplateSertifikatRequest": {
+            "type": "object",
+            "required": [
+                "judul",
+                "keterangan",
+                "penandatangan"
+            ],
+            "properties": {
+                "judul": {
+                    "type": "string"
+                },
+                "keterangan": {
+                    "type": "string"
+                },
+                "penandatangan": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.Penandatangan"
+                    }
+                }
+            }
+        },
+        "controller.UkmFeedbackStats": {
+            "type": "object",
+            "properties": {
+                "averageRating": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "ukm": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.UkmStats": {
+            "type": "object",
+            "properties": {
+                "attendees": {
+                    "type": "integer"
+                },
+                "coHosted": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "ukm": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.UpdatePanitiaRequest": {
+            "type": "object",
+            "required": [
+                "peran"
+            ],
+            "properties": {
+                "divisi": {
+                    "type": "string"
+                },
+                "peran": {
+                    "type": "string",
+                    "enum": [
+                        "ketua_pelaksana",
+                        "sekretaris",
+                        "bendahara",
+                        "divisi"
+                    ]
+                }
+            }
+        },
+        "controller.UpdateStatusTugasRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "todo",
+                        "in_progress",
+                        "done"
+                    ]
+                }
+            }
+        },
+        "controller.VarianKategori": {
+            "type": "object",
+            "properties": {
+                "kategori": {
+                    "type": "string"
+                },
+                "realisasi": {
+                    "type": "number"
+                },
+                "rencana": {
+                    "type": "number"
+                },
+                "selisih": {
+                    "type": "number"
+                }
+            }
+        },
+        "controller.VerifikasiKehadiranRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "catatan": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "rejected"
+                    ]
+                }
+            }
+        },
+        "controller.VerifikasiSertifikatResponse": {
+            "type": "object",
+            "properties": {
+                "issued_at": {
+                    "type": "string"
+                },
+                "judul_kegiatan": {
+                    "type": "string"
+                },
+                "nama_peserta": {
+                    "type": "string"
+                },
+                "serial": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "ukm": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.AnggaranItem": {
+            "type": "object",
+            "required": [
+                "jenis",
+                "kategori",
+                "uraian"
+            ],
+            "properties": {
+                "bukti_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jenis": {
+                    "type": "string",
+                    "enum": [
+                        "rencana",
+                        "pengeluaran",
+                        "pemasukan"
+                    ]
+                },
+                "jumlah": {
+                    "type": "number"
+                },
+                "kategori": {
+                    "type": "string"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "uraian": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ImportJob": {
+            "type": "object",
+            "properties": {
+                "baris_valid": {
+                    "type": "integer"
+                },
+                "berhasil": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "diproses": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportRowError"
+                    }
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "gagal": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jenis": {
+                    "type": "string"
+                },
+                "nama_file": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_baris": {
+                    "type": "integer"
+                },
+                "ukm": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "baris": {
+                    "type": "integer"
+                },
+                "pesan": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.JendelaCheckin": {
+            "type": "object",
+            "properties": {
+                "buka_sebelum_menit": {
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 0
+                },
+                "toleransi_terlambat_menit": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0
+                },
+                "tutup_setelah_menit": {
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 0
+                }
+            }
+        },
+        "model.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "locked_by": {
+                    "type": "string"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tipe": {
+                    "type": "string"
+                },
+                "unique_key": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Kategori": {
+            "type": "object",
+            "required": [
+                "nama_kategori"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kategori_utama": {
+                    "type": "string"
+                },
+                "nama_kategori": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Kegiatan": {
+            "type": "object",
+            "required": [
+                "co_hosts",
+                "judul",
+                "tanggal"
+            ],
+            "properties": {
+                "approval_status": {
+                    "type": "string"
+                },
+                "co_hosts": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
+                "deskripsi": {
+                    "type": "string"
+                },
+                "dokumentasi_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jendela_checkin": {
+                    "$ref": "#/definitions/model.JendelaCheckin"
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "kategori": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "lokasi": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "maxParticipants": {
+                    "type": "integer"
+                },
+                "publik": {
+                    "type": "boolean"
+                },
+                "radius_meter": {
+                    "type": "integer",
+                    "maximum": 5000,
+                    "minimum": 10
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.KegiatanReview"
+                    }
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "tanggal_selesai": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.KegiatanReview": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "komentar": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Kehadiran": {
+            "type": "object",
+            "required": [
+                "kegiatan_id",
+                "status",
+                "user_id"
+            ],
+            "properties": {
+                "alasan": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "catatan_verifikasi": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
+                "dokumen_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "lokasi_cek": {
+                    "$ref": "#/definitions/model.LokasiCek"
+                },
+                "metode": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "hadir",
+                        "terlambat",
+                        "izin",
+                        "sakit",
+                        "alpa",
+                        "tidak"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                },
+                "verifikasi": {
+                    "type": "string"
+                },
+                "waktu_cek": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LPJ": {
+            "type": "object",
+            "required": [
+                "narasi"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "lampiran": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LampiranLPJ"
+                    }
+                },
+                "narasi": {
+                    "type": "string"
+                },
+                "realisasi_anggaran": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RealisasiAnggaran"
+                    }
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LPJReview"
+                    }
+                },
+                "ringkasan_kehadiran": {
+                    "$ref": "#/definitions/model.RingkasanKehadiran"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "total_anggaran": {
+                    "type": "number"
+                },
+                "total_realisasi": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LPJReview": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "komentar": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LampiranLPJ": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "keterangan": {
+                    "type": "string"
+                },
+                "nama_file": {
+                    "type": "string"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                },
+                "uploaded_by": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LokasiCek": {
+            "type": "object",
+            "properties": {
+                "akurasi": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "jarak_meter": {
+                    "type": "number"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "model.Notifikasi": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "dibaca": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "pesan": {
+                    "type": "string"
+                },
+                "ref_id": {
+                    "type": "string"
+                },
+                "tipe": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Panitia": {
             "type": "object",
+            "required": [
+                "peran",
+                "user_id"
+            ],
             "properties": {
-                "attendees": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "date": {
+                "created_by": {
                     "type": "string"
                 },
-                "title": {
+                "divisi": {
                     "type": "string"
                 },
-                "ukm": {
+                "id": {
+                    "type": "string"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "peran": {
+                    "type": "string",
+                    "enum": [
+                        "ketua_pelaksana",
+                        "sekretaris",
+                        "bendahara",
+                        "divisi"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "controller.MemberStats": {
+        "model.Penandatangan": {
             "type": "object",
+            "required": [
+                "jabatan",
+                "nama"
+            ],
             "properties": {
-                "adminCount": {
-                    "type": "integer"
+                "jabatan": {
+                    "type": "string"
                 },
-                "memberCount": {
-                    "type": "integer"
+                "nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RealisasiAnggaran": {
+            "type": "object",
+            "required": [
+                "uraian"
+            ],
+            "properties": {
+                "anggaran": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "total": {
-                    "type": "integer"
+                "realisasi": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "ukm": {
+                "uraian": {
                     "type": "string"
                 }
             }
         },
-        "controller.StatisticsResponse": {
+        "model.RingkasanKehadiran": {
             "type": "object",
             "properties": {
-                "kegiatanByStatus": {
+                "per_status": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "integer"
                     }
                 },
-                "kegiatanByUkm": {
+                "tingkat_kehadiran": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Sertifikat": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issued_by": {
+                    "type": "string"
+                },
+                "judul_kegiatan": {
+                    "type": "string"
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "nama_peserta": {
+                    "type": "string"
+                },
+                "serial": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "ukm": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Tamu": {
+            "type": "object",
+            "required": [
+                "email",
+                "nama"
+            ],
+            "properties": {
+                "converted_user_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "institusi": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "kegiatan_id": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "no_hp": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "status_kehadiran": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "waktu_cek": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TemplateAnggaran": {
+            "type": "object",
+            "properties": {
+                "jumlah": {
+                    "type": "number"
+                },
+                "kategori": {
+                    "type": "string"
+                },
+                "uraian": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TemplateKegiatan": {
+            "type": "object",
+            "properties": {
+                "anggaran": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/controller.UkmStats"
+                        "$ref": "#/definitions/model.TemplateAnggaran"
                     }
                 },
-                "membersByUkm": {
+                "co_hosts": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/controller.MemberStats"
+                        "type": "string"
                     }
                 },
-                "recentActivities": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deskripsi": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jendela_checkin": {
+                    "$ref": "#/definitions/model.JendelaCheckin"
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "lokasi": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "maxParticipants": {
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "panitia": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/controller.ActivityStats"
+                        "$ref": "#/definitions/model.TemplatePanitia"
                     }
                 },
-                "totalAnggota": {
-                    "type": "integer"
+                "publik": {
+                    "type": "boolean"
                 },
-                "totalKegiatan": {
+                "radius_meter": {
                     "type": "integer"
                 },
-                "totalKehadiran": {
-                    "type": "integer"
-                }
-            }
-        },
-        "controller.UkmStats": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
+                "ukm": {
+                    "type": "string"
                 },
-                "percentage": {
-                    "type": "number"
+                "updated_at": {
+                    "type": "string"
                 },
-                "ukm": {
+                "updated_by": {
                     "type": "string"
                 }
             }
         },
-        "model.Kategori": {
+        "model.TemplatePanitia": {
             "type": "object",
             "properties": {
-                "id": {
+                "divisi": {
                     "type": "string"
                 },
-                "kategori_utama": {
+                "peran": {
                     "type": "string"
                 },
-                "nama_kategori": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "model.Kegiatan": {
+        "model.TemplateSertifikat": {
             "type": "object",
             "required": [
                 "judul",
-                "tanggal"
+                "keterangan",
+                "penandatangan"
             ],
             "properties": {
-                "created_by": {
+                "created_at": {
                     "type": "string"
                 },
-                "deskripsi": {
-                    "type": "string"
-                },
-                "dokumentasi_url": {
+                "created_by": {
                     "type": "string"
                 },
                 "id": {
@@ -1244,45 +8380,70 @@ const docTemplate = `{
                 "judul": {
                     "type": "string"
                 },
-                "kategori": {
+                "kegiatan_id": {
                     "type": "string"
                 },
-                "lokasi": {
+                "keterangan": {
                     "type": "string"
                 },
-                "maxParticipants": {
-                    "type": "integer"
+                "penandatangan": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.Penandatangan"
+                    }
                 },
-                "tanggal": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
                     "type": "string"
                 }
             }
         },
-        "model.Kehadiran": {
+        "model.Tugas": {
             "type": "object",
             "required": [
-                "kegiatan_id",
-                "status",
-                "user_id"
+                "judul"
             ],
             "properties": {
+                "assignee_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deskripsi": {
+                    "type": "string"
+                },
+                "due_date": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
+                "judul": {
+                    "type": "string"
+                },
                 "kegiatan_id": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string",
                     "enum": [
-                        "hadir",
-                        "tidak"
+                        "todo",
+                        "in_progress",
+                        "done"
                     ]
                 },
-                "user_id": {
+                "updated_at": {
                     "type": "string"
                 },
-                "waktu_cek": {
+                "updated_by": {
                     "type": "string"
                 }
             }
@@ -1297,6 +8458,18 @@ const docTemplate = `{
                 "ukm"
             ],
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1316,11 +8489,18 @@ const docTemplate = `{
                     "type": "string",
                     "enum": [
                         "admin",
-                        "member"
+                        "member",
+                        "reviewer"
                     ]
                 },
                 "ukm": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
                 }
             }
         }
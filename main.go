@@ -1,66 +1,95 @@
-package main
-
-import (
-	"log"
-	"os"
-
-	"backend-sisteminformasi/config"
-	_ "backend-sisteminformasi/docs"
-	"backend-sisteminformasi/middleware"
-	"backend-sisteminformasi/routes"
-
-	fiberswagger "github.com/swaggo/fiber-swagger"
-
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/joho/godotenv"
-)
-
-// @title Sistem Informasi UKM Kampus API
-// @version 1.0
-// @description API untuk tugas besar backend Fiber + MongoDB
-// @host backend-sisteminformasi-production.up.railway.app
-// @BasePath /
-// @schemes http https
-// @securityDefinitions.apikey BearerAuth
-// @in header
-// @name Authorization
-
-func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
-	}
-
-	config.ConnectDB()
-
-	// Seed admin user jika belum ada
-	config.SeedAdminUser(config.DB)
-
-	app := fiber.New()
-
-	// Logger middleware
-	app.Use(middleware.Logger())
-
-	// CORS middleware (hanya satu, konfigurasi sudah benar)
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-	}))
-
-	// Swagger endpoint
-	app.Get("/swagger/*", fiberswagger.WrapHandler)
-
-	// Setup all routes
-	routes.SetupRoutes(app)
-
-	// Health check endpoint for Railway/Render
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.SendString("OK")
-	})
-
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "3000"
-	}
-	log.Fatal(app.Listen(":" + port))
-}
+package main
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/controller"
+	_ "backend-sisteminformasi/docs"
+	"backend-sisteminformasi/middleware"
+	"backend-sisteminformasi/routes"
+	"backend-sisteminformasi/scheduler"
+
+	fiberswagger "github.com/swaggo/fiber-swagger"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/joho/godotenv"
+)
+
+// @title Sistem Informasi UKM Kampus API
+// @version 1.0
+// @description API untuk tugas besar backend Fiber + MongoDB
+// @host backend-sisteminformasi-production.up.railway.app
+// @BasePath /
+// @schemes http https
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	config.ConnectDB()
+	// Migrasi dijalankan sebelum index agar index unik kehadiran tidak gagal
+	config.MigrateKehadiranRefs(config.DB)
+	config.EnsureIndexes(config.DB)
+
+	// Seed admin user jika belum ada
+	config.SeedAdminUser(config.DB)
+
+	// Job latar belakang: pengingat kegiatan dan pembersihan tempat sampah
+	controller.RegisterJobs()
+	scheduler.Start()
+
+	// Di belakang reverse proxy (Railway/Render) IP klien dibaca dari header ini,
+	// dipakai untuk rate limit endpoint publik. Header hanya dipercaya jika request
+	// datang dari alamat di TRUSTED_PROXIES, selain itu dipakai IP koneksi.
+	app := fiber.New(fiber.Config{
+		ProxyHeader:             os.Getenv("PROXY_HEADER"),
+		EnableIPValidation:      true,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies(),
+	})
+
+	// Logger middleware
+	app.Use(middleware.Logger())
+
+	// CORS middleware (hanya satu, konfigurasi sudah benar)
+	app.Use(cors.New(cors.Config{
+		AllowOrigins: "*",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+	}))
+
+	// Swagger endpoint
+	app.Get("/swagger/*", fiberswagger.WrapHandler)
+
+	// Setup all routes
+	routes.SetupRoutes(app)
+
+	// Health check endpoint for Railway/Render
+	app.Get("/health", func(c *fiber.Ctx) error {
+		return c.SendString("OK")
+	})
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "3000"
+	}
+	log.Fatal(app.Listen(":" + port))
+}
+
+// trustedProxies membaca daftar IP/CIDR proxy terpercaya dari TRUSTED_PROXIES (dipisah koma)
+func trustedProxies() []string {
+	proxies := []string{}
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
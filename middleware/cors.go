@@ -1,10 +1,10 @@
-package middleware
-
-import (
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-)
-
-func Cors() fiber.Handler {
-	return cors.New()
-}
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+func Cors() fiber.Handler {
+	return cors.New()
+}
@@ -1,10 +1,10 @@
-package middleware
-
-import (
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
-)
-
-func Logger() fiber.Handler {
-	return logger.New()
-}
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+)
+
+func Logger() fiber.Handler {
+	return logger.New()
+}
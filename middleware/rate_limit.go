@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+// PublicRateLimit membatasi jumlah request per IP untuk endpoint tanpa login.
+// Batas per menit diatur lewat PUBLIC_RATE_LIMIT (default 60).
+func PublicRateLimit() fiber.Handler {
+	max, err := strconv.Atoi(os.Getenv("PUBLIC_RATE_LIMIT"))
+	if err != nil || max <= 0 {
+		max = 60
+	}
+	return limiter.New(limiter.Config{
+		Max:        max,
+		Expiration: time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "Too many requests, please try again later"})
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Jenis item anggaran kegiatan
+const (
+	AnggaranRencana     = "rencana"
+	AnggaranPengeluaran = "pengeluaran"
+	AnggaranPemasukan   = "pemasukan"
+)
+
+// AnggaranItem adalah satu baris anggaran kegiatan: rencana biaya, pengeluaran aktual,
+// atau pemasukan (sponsorship, tiket, dll). Kategori dipakai untuk membandingkan
+// rencana dengan realisasi per pos anggaran.
+type AnggaranItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	KegiatanID primitive.ObjectID `bson:"kegiatan_id" json:"kegiatan_id"`
+	Jenis      string             `bson:"jenis" json:"jenis" validate:"required,oneof=rencana pengeluaran pemasukan"`
+	Kategori   string             `bson:"kategori" json:"kategori" validate:"required"`
+	Uraian     string             `bson:"uraian" json:"uraian" validate:"required"`
+	Jumlah     float64            `bson:"jumlah" json:"jumlah" validate:"gt=0"`
+	Tanggal    string             `bson:"tanggal" json:"tanggal"`
+	BuktiURL   string             `bson:"bukti_url" json:"bukti_url"`
+	CreatedBy  string             `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedBy  string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
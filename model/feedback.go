@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Feedback adalah penilaian peserta setelah mengikuti kegiatan.
+// UserID tetap disimpan untuk mencegah penilaian ganda walaupun anonim.
+type Feedback struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	KegiatanID primitive.ObjectID `bson:"kegiatan_id" json:"kegiatan_id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id,omitempty"`
+	Rating     int                `bson:"rating" json:"rating" validate:"required,min=1,max=5"`
+	Komentar   string             `bson:"komentar" json:"komentar" validate:"max=2000"`
+	Anonim     bool               `bson:"anonim" json:"anonim"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ImportJob mencatat proses import data massal. Baris valid disimpan sementara
+// di Rows dan dihapus setelah import selesai. Status memakai konstanta Job*.
+// UKM adalah UKM admin yang mengimport, dipakai membatasi siapa yang dapat melihat progres.
+type ImportJob struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Jenis      string             `bson:"jenis" json:"jenis"`
+	NamaFile   string             `bson:"nama_file" json:"nama_file"`
+	Status     string             `bson:"status" json:"status"`
+	TotalBaris int                `bson:"total_baris" json:"total_baris"`
+	BarisValid int                `bson:"baris_valid" json:"baris_valid"`
+	Diproses   int                `bson:"diproses" json:"diproses"`
+	Berhasil   int                `bson:"berhasil" json:"berhasil"`
+	Gagal      int                `bson:"gagal" json:"gagal"`
+	Errors     []ImportRowError   `bson:"errors" json:"errors"`
+	Rows       []ImportRow        `bson:"rows,omitempty" json:"-"`
+	UKM        string             `bson:"ukm,omitempty" json:"ukm,omitempty"`
+	CreatedBy  string             `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+	FinishedAt *time.Time         `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+}
+
+// ImportRowError menunjuk nomor baris pada file (header = baris 1)
+type ImportRowError struct {
+	Baris int    `bson:"baris" json:"baris"`
+	Pesan string `bson:"pesan" json:"pesan"`
+}
+
+// ImportRow.ID adalah _id dokumen yang akan dibuat, ditetapkan sejak awal agar
+// batch yang diulang tidak menyimpan baris yang sama dua kali
+type ImportRow struct {
+	ID    primitive.ObjectID `bson:"id,omitempty" json:"-"`
+	Baris int                `bson:"baris" json:"baris"`
+	Data  map[string]string  `bson:"data" json:"data"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status job pada scheduler
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// Job adalah pekerjaan latar belakang yang disimpan di MongoDB. Job dengan
+// Interval > 0 dijadwalkan ulang setelah selesai. UniqueKey mencegah job yang
+// sama dibuat dua kali.
+type Job struct {
+	ID          primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	Tipe        string                 `bson:"tipe" json:"tipe"`
+	UniqueKey   string                 `bson:"unique_key,omitempty" json:"unique_key,omitempty"`
+	Payload     map[string]interface{} `bson:"payload,omitempty" json:"payload,omitempty"`
+	RunAt       time.Time              `bson:"run_at" json:"run_at"`
+	Interval    time.Duration          `bson:"interval,omitempty" json:"interval,omitempty" swaggertype:"integer"`
+	Status      string                 `bson:"status" json:"status"`
+	Attempts    int                    `bson:"attempts" json:"attempts"`
+	MaxAttempts int                    `bson:"max_attempts" json:"max_attempts"`
+	LockedBy    string                 `bson:"locked_by,omitempty" json:"locked_by,omitempty"`
+	LockedUntil *time.Time             `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+	LastError   string                 `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	FinishedAt  *time.Time             `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+	CreatedAt   time.Time              `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time              `bson:"updated_at" json:"updated_at"`
+}
@@ -1,7 +1,15 @@
-package model
-
-type Kategori struct {
-	ID            string `bson:"_id,omitempty" json:"id"`
-	NamaKategori  string `bson:"nama_kategori" json:"nama_kategori"`
-	KategoriUtama string `bson:"kategori_utama" json:"kategori_utama"`
-}
+package model
+
+import "time"
+
+type Kategori struct {
+	ID            string     `bson:"_id,omitempty" json:"id"`
+	NamaKategori  string     `bson:"nama_kategori" json:"nama_kategori" validate:"required"`
+	KategoriUtama string     `bson:"kategori_utama" json:"kategori_utama"`
+	CreatedBy     string     `bson:"created_by" json:"created_by"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedBy     string     `bson:"updated_by" json:"updated_by"`
+	UpdatedAt     time.Time  `bson:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy     string     `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+}
@@ -1,15 +1,59 @@
-package model
-
-import "go.mongodb.org/mongo-driver/bson/primitive"
-
-type Kegiatan struct {
-	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Judul           string             `bson:"judul" json:"judul" validate:"required"`
-	Deskripsi       string             `bson:"deskripsi" json:"deskripsi"`
-	Tanggal         string             `bson:"tanggal" json:"tanggal" validate:"required"`
-	Lokasi          string             `bson:"lokasi" json:"lokasi"`
-	Kategori        string             `bson:"kategori" json:"kategori"`
-	MaxParticipants int                `bson:"maxParticipants" json:"maxParticipants"`
-	DokumentasiURL  string             `bson:"dokumentasi_url" json:"dokumentasi_url"`
-	CreatedBy       string             `bson:"created_by" json:"created_by"`
-}
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status pengajuan kegiatan. Kegiatan lama tanpa approval_status dianggap approved.
+const (
+	KegiatanDraft     = "draft"
+	KegiatanSubmitted = "submitted"
+	KegiatanApproved  = "approved"
+	KegiatanRejected  = "rejected"
+)
+
+// Kegiatan dengan Latitude/Longitude membatasi check-in mandiri dalam RadiusMeter
+// dari titik tersebut (default 100 meter)
+type Kegiatan struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Judul           string             `bson:"judul" json:"judul" validate:"required"`
+	Deskripsi       string             `bson:"deskripsi" json:"deskripsi"`
+	Tanggal         string             `bson:"tanggal" json:"tanggal" validate:"required"`
+	TanggalSelesai  string             `bson:"tanggal_selesai,omitempty" json:"tanggal_selesai,omitempty"`
+	JendelaCheckin  *JendelaCheckin    `bson:"jendela_checkin,omitempty" json:"jendela_checkin,omitempty"`
+	Lokasi          string             `bson:"lokasi" json:"lokasi"`
+	Latitude        *float64           `bson:"latitude,omitempty" json:"latitude,omitempty" validate:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude       *float64           `bson:"longitude,omitempty" json:"longitude,omitempty" validate:"required_with=Latitude,omitempty,min=-180,max=180"`
+	RadiusMeter     int                `bson:"radius_meter,omitempty" json:"radius_meter,omitempty" validate:"omitempty,min=10,max=5000"`
+	Kategori        string             `bson:"kategori" json:"kategori"`
+	CoHosts         []string           `bson:"co_hosts,omitempty" json:"co_hosts,omitempty" validate:"max=5,dive,required"`
+	MaxParticipants int                `bson:"maxParticipants" json:"maxParticipants"`
+	DokumentasiURL  string             `bson:"dokumentasi_url" json:"dokumentasi_url"`
+	Publik          bool               `bson:"publik" json:"publik"`
+	ApprovalStatus  string             `bson:"approval_status" json:"approval_status"`
+	Reviews         []KegiatanReview   `bson:"reviews,omitempty" json:"reviews,omitempty"`
+	CreatedBy       string             `bson:"created_by" json:"created_by"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedBy       string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
+	DeletedAt       *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy       string             `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+}
+
+// JendelaCheckin mengatur kapan check-in mandiri dibuka dan ditutup relatif terhadap
+// jadwal kegiatan, serta toleransi sebelum peserta dicatat terlambat
+type JendelaCheckin struct {
+	BukaSebelumMenit        int `bson:"buka_sebelum_menit" json:"buka_sebelum_menit" validate:"min=0,max=10080"`
+	TutupSetelahMenit       int `bson:"tutup_setelah_menit" json:"tutup_setelah_menit" validate:"min=0,max=10080"`
+	ToleransiTerlambatMenit int `bson:"toleransi_terlambat_menit" json:"toleransi_terlambat_menit" validate:"min=0,max=1440"`
+}
+
+// KegiatanReview mencatat setiap keputusan reviewer beserta komentarnya
+type KegiatanReview struct {
+	ReviewerID string    `bson:"reviewer_id" json:"reviewer_id"`
+	Status     string    `bson:"status" json:"status"`
+	Komentar   string    `bson:"komentar" json:"komentar"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+}
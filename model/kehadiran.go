@@ -1,9 +1,69 @@
-package model
-
-type Kehadiran struct {
-	ID         string `bson:"_id,omitempty" json:"id"`
-	UserID     string `bson:"user_id" json:"user_id" validate:"required"`
-	KegiatanID string `bson:"kegiatan_id" json:"kegiatan_id" validate:"required"`
-	Status     string `bson:"status" json:"status" validate:"required,oneof=hadir tidak"`
-	WaktuCek   string `bson:"waktu_cek" json:"waktu_cek"`
-}
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status kehadiran. Izin dan sakit adalah ketidakhadiran dengan alasan yang perlu
+// disetujui admin; "tidak" adalah status lama yang diperlakukan sama dengan alpa.
+const (
+	StatusHadir     = "hadir"
+	StatusTerlambat = "terlambat"
+	StatusIzin      = "izin"
+	StatusSakit     = "sakit"
+	StatusAlpa      = "alpa"
+	StatusTidak     = "tidak"
+)
+
+// Metode pencatatan kehadiran: oleh peserta sendiri, lewat scan QR, diinput admin,
+// atau disinkronkan dari aplikasi panitia yang mencatat secara offline
+const (
+	MetodeSelf    = "self"
+	MetodeQR      = "qr"
+	MetodeAdmin   = "admin"
+	MetodeImport  = "import"
+	MetodeOffline = "offline"
+)
+
+// Status verifikasi untuk kehadiran yang check-in di luar radius lokasi kegiatan
+// dan pengajuan izin/sakit
+const (
+	VerifikasiPending  = "pending"
+	VerifikasiApproved = "approved"
+	VerifikasiRejected = "rejected"
+)
+
+// LokasiCek adalah koordinat perangkat saat check-in, JarakMeter dihitung server
+type LokasiCek struct {
+	Latitude   float64 `bson:"latitude" json:"latitude" validate:"min=-90,max=90"`
+	Longitude  float64 `bson:"longitude" json:"longitude" validate:"min=-180,max=180"`
+	Akurasi    float64 `bson:"akurasi,omitempty" json:"akurasi,omitempty" validate:"min=0"`
+	JarakMeter float64 `bson:"jarak_meter" json:"jarak_meter"`
+}
+
+// Kehadiran mencatat status satu user pada satu kegiatan. ClientID adalah ID buatan
+// aplikasi panitia untuk check-in offline agar sinkronisasi ulang tidak tercatat dua kali.
+type Kehadiran struct {
+	ID                string             `bson:"_id,omitempty" json:"id"`
+	UserID            primitive.ObjectID `bson:"user_id" json:"user_id" validate:"required"`
+	KegiatanID        primitive.ObjectID `bson:"kegiatan_id" json:"kegiatan_id" validate:"required"`
+	Status            string             `bson:"status" json:"status" validate:"required,oneof=hadir terlambat izin sakit alpa tidak"`
+	WaktuCek          string             `bson:"waktu_cek" json:"waktu_cek"`
+	Metode            string             `bson:"metode,omitempty" json:"metode,omitempty"`
+	LokasiCek         *LokasiCek         `bson:"lokasi_cek,omitempty" json:"lokasi_cek,omitempty"`
+	Alasan            string             `bson:"alasan,omitempty" json:"alasan,omitempty" validate:"max=1000"`
+	DokumenURL        string             `bson:"dokumen_url,omitempty" json:"dokumen_url,omitempty"`
+	ClientID          string             `bson:"client_id,omitempty" json:"client_id,omitempty"`
+	Verifikasi        string             `bson:"verifikasi,omitempty" json:"verifikasi,omitempty"`
+	CatatanVerifikasi string             `bson:"catatan_verifikasi,omitempty" json:"catatan_verifikasi,omitempty"`
+	VerifiedBy        string             `bson:"verified_by,omitempty" json:"verified_by,omitempty"`
+	VerifiedAt        *time.Time         `bson:"verified_at,omitempty" json:"verified_at,omitempty"`
+	CreatedBy         string             `bson:"created_by" json:"created_by"`
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedBy         string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
+	DeletedAt         *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy         string             `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+}
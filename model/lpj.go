@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status laporan pertanggungjawaban (LPJ)
+const (
+	LPJDraft     = "draft"
+	LPJSubmitted = "submitted"
+	LPJApproved  = "approved"
+	LPJRevision  = "revision"
+)
+
+type LPJ struct {
+	ID                 primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	KegiatanID         primitive.ObjectID  `bson:"kegiatan_id" json:"kegiatan_id"`
+	Narasi             string              `bson:"narasi" json:"narasi" validate:"required"`
+	RingkasanKehadiran RingkasanKehadiran  `bson:"ringkasan_kehadiran" json:"ringkasan_kehadiran"`
+	Lampiran           []LampiranLPJ       `bson:"lampiran" json:"lampiran"`
+	RealisasiAnggaran  []RealisasiAnggaran `bson:"realisasi_anggaran" json:"realisasi_anggaran" validate:"dive"`
+	TotalAnggaran      float64             `bson:"total_anggaran" json:"total_anggaran"`
+	TotalRealisasi     float64             `bson:"total_realisasi" json:"total_realisasi"`
+	Status             string              `bson:"status" json:"status"`
+	Reviews            []LPJReview         `bson:"reviews,omitempty" json:"reviews,omitempty"`
+	SubmittedAt        *time.Time          `bson:"submitted_at,omitempty" json:"submitted_at,omitempty"`
+	CreatedBy          string              `bson:"created_by" json:"created_by"`
+	CreatedAt          time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedBy          string              `bson:"updated_by" json:"updated_by"`
+	UpdatedAt          time.Time           `bson:"updated_at" json:"updated_at"`
+}
+
+// RingkasanKehadiran dihitung otomatis dari koleksi kehadiran
+type RingkasanKehadiran struct {
+	Total            int64            `bson:"total" json:"total"`
+	PerStatus        map[string]int64 `bson:"per_status" json:"per_status"`
+	TingkatKehadiran float64          `bson:"tingkat_kehadiran" json:"tingkat_kehadiran"`
+}
+
+type LampiranLPJ struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	NamaFile   string             `bson:"nama_file" json:"nama_file"`
+	URL        string             `bson:"url" json:"url"`
+	Keterangan string             `bson:"keterangan" json:"keterangan"`
+	UploadedBy string             `bson:"uploaded_by" json:"uploaded_by"`
+	UploadedAt time.Time          `bson:"uploaded_at" json:"uploaded_at"`
+}
+
+type RealisasiAnggaran struct {
+	Uraian    string  `bson:"uraian" json:"uraian" validate:"required"`
+	Anggaran  float64 `bson:"anggaran" json:"anggaran" validate:"gte=0"`
+	Realisasi float64 `bson:"realisasi" json:"realisasi" validate:"gte=0"`
+}
+
+type LPJReview struct {
+	ReviewerID string    `bson:"reviewer_id" json:"reviewer_id"`
+	Status     string    `bson:"status" json:"status"`
+	Komentar   string    `bson:"komentar" json:"komentar"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Notifikasi struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Judul     string             `bson:"judul" json:"judul"`
+	Pesan     string             `bson:"pesan" json:"pesan"`
+	Tipe      string             `bson:"tipe" json:"tipe"`
+	RefID     primitive.ObjectID `bson:"ref_id,omitempty" json:"ref_id,omitempty"`
+	Dibaca    bool               `bson:"dibaca" json:"dibaca"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Peran anggota panitia kegiatan
+const (
+	PeranKetuaPelaksana = "ketua_pelaksana"
+	PeranSekretaris     = "sekretaris"
+	PeranBendahara      = "bendahara"
+	PeranDivisi         = "divisi"
+)
+
+// Panitia adalah keanggotaan seorang user dalam kepanitiaan kegiatan.
+// Divisi wajib diisi untuk peran "divisi" (mis. acara, konsumsi, humas).
+type Panitia struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	KegiatanID primitive.ObjectID `bson:"kegiatan_id" json:"kegiatan_id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id" validate:"required"`
+	Peran      string             `bson:"peran" json:"peran" validate:"required,oneof=ketua_pelaksana sekretaris bendahara divisi"`
+	Divisi     string             `bson:"divisi" json:"divisi" validate:"required_if=Peran divisi"`
+	CreatedBy  string             `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedBy  string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
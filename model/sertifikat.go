@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TemplateSertifikat adalah pengaturan sertifikat untuk satu kegiatan
+type TemplateSertifikat struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	KegiatanID    primitive.ObjectID `bson:"kegiatan_id" json:"kegiatan_id"`
+	Judul         string             `bson:"judul" json:"judul" validate:"required"`
+	Keterangan    string             `bson:"keterangan" json:"keterangan" validate:"required"`
+	Penandatangan []Penandatangan    `bson:"penandatangan" json:"penandatangan" validate:"required,min=1,max=3,dive"`
+	CreatedBy     string             `bson:"created_by" json:"created_by"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedBy     string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type Penandatangan struct {
+	Nama    string `bson:"nama" json:"nama" validate:"required"`
+	Jabatan string `bson:"jabatan" json:"jabatan" validate:"required"`
+}
+
+// Sertifikat yang sudah diterbitkan. Data peserta dan kegiatan disalin saat
+// penerbitan agar hasil verifikasi tidak berubah ketika data sumber diedit.
+type Sertifikat struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Serial        string             `bson:"serial" json:"serial"`
+	KegiatanID    primitive.ObjectID `bson:"kegiatan_id" json:"kegiatan_id"`
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
+	NamaPeserta   string             `bson:"nama_peserta" json:"nama_peserta"`
+	JudulKegiatan string             `bson:"judul_kegiatan" json:"judul_kegiatan"`
+	Tanggal       string             `bson:"tanggal" json:"tanggal"`
+	UKM           string             `bson:"ukm" json:"ukm"`
+	IssuedBy      string             `bson:"issued_by" json:"issued_by"`
+	IssuedAt      time.Time          `bson:"issued_at" json:"issued_at"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tamu adalah peserta non-anggota yang mendaftar pada kegiatan publik.
+// StatusKehadiran kosong berarti kehadirannya belum dicatat.
+type Tamu struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	KegiatanID      primitive.ObjectID `bson:"kegiatan_id" json:"kegiatan_id"`
+	Nama            string             `bson:"nama" json:"nama" validate:"required,min=2,max=100"`
+	Email           string             `bson:"email" json:"email" validate:"required,email"`
+	Institusi       string             `bson:"institusi" json:"institusi" validate:"max=150"`
+	NoHP            string             `bson:"no_hp" json:"no_hp" validate:"max=20"`
+	StatusKehadiran string             `bson:"status_kehadiran,omitempty" json:"status_kehadiran,omitempty"`
+	WaktuCek        string             `bson:"waktu_cek,omitempty" json:"waktu_cek,omitempty"`
+	ConvertedUserID primitive.ObjectID `bson:"converted_user_id,omitempty" json:"converted_user_id,omitempty"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedBy       string             `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TemplateKegiatan menyimpan struktur kegiatan yang bisa dipakai ulang oleh
+// UKM yang sama: detail kegiatan, susunan panitia, dan rencana anggaran.
+type TemplateKegiatan struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Nama            string             `bson:"nama" json:"nama"`
+	UKM             string             `bson:"ukm" json:"ukm"`
+	CoHosts         []string           `bson:"co_hosts,omitempty" json:"co_hosts,omitempty"`
+	Judul           string             `bson:"judul" json:"judul"`
+	Deskripsi       string             `bson:"deskripsi" json:"deskripsi"`
+	Lokasi          string             `bson:"lokasi" json:"lokasi"`
+	Latitude        *float64           `bson:"latitude,omitempty" json:"latitude,omitempty"`
+	Longitude       *float64           `bson:"longitude,omitempty" json:"longitude,omitempty"`
+	RadiusMeter     int                `bson:"radius_meter,omitempty" json:"radius_meter,omitempty"`
+	MaxParticipants int                `bson:"maxParticipants" json:"maxParticipants"`
+	JendelaCheckin  *JendelaCheckin    `bson:"jendela_checkin,omitempty" json:"jendela_checkin,omitempty"`
+	Publik          bool               `bson:"publik" json:"publik"`
+	Panitia         []TemplatePanitia  `bson:"panitia" json:"panitia"`
+	Anggaran        []TemplateAnggaran `bson:"anggaran" json:"anggaran"`
+	CreatedBy       string             `bson:"created_by" json:"created_by"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedBy       string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type TemplatePanitia struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Peran  string             `bson:"peran" json:"peran"`
+	Divisi string             `bson:"divisi" json:"divisi"`
+}
+
+type TemplateAnggaran struct {
+	Kategori string  `bson:"kategori" json:"kategori"`
+	Uraian   string  `bson:"uraian" json:"uraian"`
+	Jumlah   float64 `bson:"jumlah" json:"jumlah"`
+}
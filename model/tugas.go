@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status tugas panitia
+const (
+	TugasTodo       = "todo"
+	TugasInProgress = "in_progress"
+	TugasDone       = "done"
+)
+
+type Tugas struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	KegiatanID primitive.ObjectID `bson:"kegiatan_id" json:"kegiatan_id"`
+	Judul      string             `bson:"judul" json:"judul" validate:"required"`
+	Deskripsi  string             `bson:"deskripsi" json:"deskripsi"`
+	AssigneeID primitive.ObjectID `bson:"assignee_id,omitempty" json:"assignee_id,omitempty"`
+	DueDate    string             `bson:"due_date" json:"due_date"`
+	Status     string             `bson:"status" json:"status" validate:"omitempty,oneof=todo in_progress done"`
+	CreatedBy  string             `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedBy  string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
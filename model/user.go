@@ -1,10 +1,18 @@
-package model
-
-type User struct {
-	ID       string `bson:"_id,omitempty" json:"id"`
-	Nama     string `bson:"nama" json:"nama" validate:"required,min=2,max=100"`
-	Email    string `bson:"email" json:"email" validate:"required,email"`
-	Password string `bson:"password" json:"password" validate:"required,min=6"`
-	Role     string `bson:"role" json:"role" validate:"required,oneof=admin member"`
-	UKM      string `bson:"ukm" json:"ukm" validate:"required"`
-}
+package model
+
+import "time"
+
+type User struct {
+	ID        string     `bson:"_id,omitempty" json:"id"`
+	Nama      string     `bson:"nama" json:"nama" validate:"required,min=2,max=100"`
+	Email     string     `bson:"email" json:"email" validate:"required,email"`
+	Password  string     `bson:"password" json:"password" validate:"required,min=6"`
+	Role      string     `bson:"role" json:"role" validate:"required,oneof=admin member reviewer"`
+	UKM       string     `bson:"ukm" json:"ukm" validate:"required"`
+	CreatedBy string     `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedBy string     `bson:"updated_by" json:"updated_by"`
+	UpdatedAt time.Time  `bson:"updated_at" json:"updated_at"`
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy string     `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AnggaranRoutes(app fiber.Router) {
+	app.Get("/anggaran/ukm", middleware.AuthRequired(), middleware.RoleRequired("admin", "reviewer"), controller.GetRingkasanAnggaranUKM)
+	app.Get("/kegiatan/:id/anggaran", middleware.AuthRequired(), middleware.RoleRequired("admin", "reviewer"), controller.GetAnggaran)
+	app.Post("/kegiatan/:id/anggaran", middleware.AuthRequired(), middleware.AdminOnly(), controller.CreateAnggaran)
+	app.Put("/kegiatan/:id/anggaran/:itemId", middleware.AuthRequired(), middleware.AdminOnly(), controller.UpdateAnggaran)
+	app.Delete("/kegiatan/:id/anggaran/:itemId", middleware.AuthRequired(), middleware.AdminOnly(), controller.DeleteAnggaran)
+	app.Post("/kegiatan/:id/anggaran/:itemId/bukti", middleware.AuthRequired(), middleware.AdminOnly(), controller.UploadBuktiAnggaran)
+}
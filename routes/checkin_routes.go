@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func CheckinRoutes(app fiber.Router) {
+	app.Get("/kegiatan/:id/checkin-qr", middleware.AuthRequired(), controller.GetCheckinQR)
+	app.Post("/kegiatan/:id/checkin", middleware.AuthRequired(), controller.CheckinKegiatan)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func FeedbackRoutes(app fiber.Router) {
+	app.Get("/feedback/ukm", middleware.AuthRequired(), middleware.RoleRequired("admin", "reviewer"), controller.GetRingkasanFeedbackUKM)
+	app.Get("/kegiatan/:id/feedback", middleware.AuthRequired(), middleware.RoleRequired("admin", "reviewer"), controller.GetFeedback)
+	app.Get("/kegiatan/:id/feedback/ringkasan", middleware.AuthRequired(), controller.GetRingkasanFeedback)
+	app.Post("/kegiatan/:id/feedback", middleware.AuthRequired(), controller.CreateFeedback)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ImportRoutes(app fiber.Router) {
+	app.Post("/import/:jenis", middleware.AuthRequired(), middleware.AdminOnly(), controller.ImportData)
+	app.Get("/import/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.GetImportJob)
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func JobRoutes(app fiber.Router) {
+	app.Get("/jobs", middleware.AuthRequired(), middleware.AdminOnly(), controller.GetJobs)
+}
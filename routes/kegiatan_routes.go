@@ -1,16 +1,19 @@
-package routes
-
-import (
-	"backend-sisteminformasi/controller"
-	"backend-sisteminformasi/middleware"
-
-	"github.com/gofiber/fiber/v2"
-)
-
-func KegiatanRoutes(app fiber.Router) {
-	app.Get("/kegiatan", middleware.AuthRequired(), controller.GetKegiatan)
-	app.Get("/kegiatan/:id", middleware.AuthRequired(), controller.GetKegiatanByID)
-	app.Post("/kegiatan", middleware.AuthRequired(), middleware.AdminOnly(), controller.CreateKegiatan)
-	app.Put("/kegiatan/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.UpdateKegiatan)
-	app.Delete("/kegiatan/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.DeleteKegiatan)
-}
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func KegiatanRoutes(app fiber.Router) {
+	app.Get("/kegiatan", middleware.AuthRequired(), controller.GetKegiatan)
+	app.Get("/kegiatan/:id", middleware.AuthRequired(), controller.GetKegiatanByID)
+	app.Post("/kegiatan", middleware.AuthRequired(), middleware.AdminOnly(), controller.CreateKegiatan)
+	app.Put("/kegiatan/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.UpdateKegiatan)
+	app.Patch("/kegiatan/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.PatchKegiatan)
+	app.Delete("/kegiatan/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.DeleteKegiatan)
+	app.Post("/kegiatan/:id/submit", middleware.AuthRequired(), middleware.AdminOnly(), controller.SubmitKegiatan)
+	app.Post("/kegiatan/:id/review", middleware.AuthRequired(), middleware.RoleRequired("reviewer"), controller.ReviewKegiatan)
+}
@@ -1,16 +1,26 @@
-package routes
-
-import (
-	"backend-sisteminformasi/controller"
-	"backend-sisteminformasi/middleware"
-
-	"github.com/gofiber/fiber/v2"
-)
-
-func KehadiranRoutes(app fiber.Router) {
-	app.Get("/kehadiran", middleware.AuthRequired(), controller.GetKehadiran)
-	app.Get("/kehadiran/:id", middleware.AuthRequired(), controller.GetKehadiranByID)
-	app.Post("/kehadiran", middleware.AuthRequired(), controller.CreateKehadiran)
-	app.Put("/kehadiran/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.UpdateKehadiran)
-	app.Delete("/kehadiran/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.DeleteKehadiran)
-}
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func KehadiranRoutes(app fiber.Router) {
+	app.Get("/kehadiran", middleware.AuthRequired(), controller.GetKehadiran)
+	app.Get("/kehadiran/flagged", middleware.AuthRequired(), middleware.AdminOnly(), controller.GetKehadiranFlagged)
+	app.Get("/kehadiran/duplicates", middleware.AuthRequired(), middleware.AdminOnly(), controller.GetKehadiranDuplikat)
+	app.Post("/kehadiran/duplicates/merge", middleware.AuthRequired(), middleware.AdminOnly(), controller.MergeKehadiranDuplikat)
+	app.Get("/kehadiran/:id", middleware.AuthRequired(), controller.GetKehadiranByID)
+	app.Post("/kehadiran", middleware.AuthRequired(), controller.CreateKehadiran)
+	app.Put("/kehadiran/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.UpdateKehadiran)
+	app.Patch("/kehadiran/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.PatchKehadiran)
+	app.Put("/kehadiran/:id/verifikasi", middleware.AuthRequired(), middleware.AdminOnly(), controller.VerifikasiKehadiran)
+	app.Post("/kegiatan/:id/kehadiran/bulk", middleware.AuthRequired(), controller.BulkKehadiran)
+	app.Post("/kegiatan/:id/kehadiran/sync", middleware.AuthRequired(), controller.SyncKehadiran)
+	app.Post("/kegiatan/:id/izin", middleware.AuthRequired(), controller.AjukanIzin)
+	app.Get("/me/kehadiran", middleware.AuthRequired(), controller.GetMyKehadiran)
+	app.Get("/users/:id/kehadiran", middleware.AuthRequired(), controller.GetUserKehadiran)
+	app.Delete("/kehadiran/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.DeleteKehadiran)
+}
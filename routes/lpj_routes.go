@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func LPJRoutes(app fiber.Router) {
+	app.Get("/lpj/overdue", middleware.AuthRequired(), middleware.RoleRequired("admin", "reviewer"), controller.GetOverdueLPJ)
+	app.Get("/kegiatan/:id/lpj", middleware.AuthRequired(), middleware.RoleRequired("admin", "reviewer"), controller.GetLPJ)
+	app.Post("/kegiatan/:id/lpj", middleware.AuthRequired(), middleware.AdminOnly(), controller.CreateLPJ)
+	app.Put("/kegiatan/:id/lpj", middleware.AuthRequired(), middleware.AdminOnly(), controller.UpdateLPJ)
+	app.Post("/kegiatan/:id/lpj/lampiran", middleware.AuthRequired(), middleware.AdminOnly(), controller.UploadLampiranLPJ)
+	app.Delete("/kegiatan/:id/lpj/lampiran/:lampiranId", middleware.AuthRequired(), middleware.AdminOnly(), controller.DeleteLampiranLPJ)
+	app.Post("/kegiatan/:id/lpj/submit", middleware.AuthRequired(), middleware.AdminOnly(), controller.SubmitLPJ)
+	app.Post("/kegiatan/:id/lpj/review", middleware.AuthRequired(), middleware.RoleRequired("reviewer"), controller.ReviewLPJ)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func NotifikasiRoutes(app fiber.Router) {
+	app.Get("/notifikasi", middleware.AuthRequired(), controller.GetNotifikasi)
+	app.Put("/notifikasi/read-all", middleware.AuthRequired(), controller.ReadAllNotifikasi)
+	app.Put("/notifikasi/:id/read", middleware.AuthRequired(), controller.ReadNotifikasi)
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func PanitiaRoutes(app fiber.Router) {
+	app.Get("/me/tugas", middleware.AuthRequired(), controller.GetMyTugas)
+	app.Get("/kegiatan/:id/panitia", middleware.AuthRequired(), controller.GetPanitia)
+	app.Post("/kegiatan/:id/panitia", middleware.AuthRequired(), controller.AddPanitia)
+	app.Put("/kegiatan/:id/panitia/:panitiaId", middleware.AuthRequired(), controller.UpdatePanitia)
+	app.Delete("/kegiatan/:id/panitia/:panitiaId", middleware.AuthRequired(), controller.RemovePanitia)
+	app.Get("/kegiatan/:id/tugas", middleware.AuthRequired(), controller.GetTugas)
+	app.Post("/kegiatan/:id/tugas", middleware.AuthRequired(), controller.CreateTugas)
+	app.Put("/kegiatan/:id/tugas/:tugasId", middleware.AuthRequired(), controller.UpdateTugas)
+	app.Patch("/kegiatan/:id/tugas/:tugasId/status", middleware.AuthRequired(), controller.UpdateStatusTugas)
+	app.Delete("/kegiatan/:id/tugas/:tugasId", middleware.AuthRequired(), controller.DeleteTugas)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/etag"
+)
+
+// PublicRoutes berisi API baca-saja untuk halaman publik kampus, tanpa login
+func PublicRoutes(app fiber.Router) {
+	public := app.Group("/public", middleware.PublicRateLimit(), etag.New())
+	public.Get("/kegiatan", controller.GetPublicKegiatan)
+	public.Get("/kegiatan/:id", controller.GetPublicKegiatanByID)
+	public.Post("/kegiatan/:id/tamu", controller.DaftarTamu)
+}
@@ -1,14 +1,28 @@
-package routes
-
-import (
-	"github.com/gofiber/fiber/v2"
-)
-
-func SetupRoutes(app *fiber.App) {
-	AuthRoutes(app)
-	UserRoutes(app)
-	KegiatanRoutes(app)
-	KehadiranRoutes(app)
-	KategoriRoutes(app)
-	StatisticsRoutes(app)
-}
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRoutes(app *fiber.App) {
+	AuthRoutes(app)
+	UserRoutes(app)
+	KegiatanRoutes(app)
+	KehadiranRoutes(app)
+	KategoriRoutes(app)
+	StatisticsRoutes(app)
+	TrashRoutes(app)
+	NotifikasiRoutes(app)
+	LPJRoutes(app)
+	AnggaranRoutes(app)
+	PanitiaRoutes(app)
+	FeedbackRoutes(app)
+	SertifikatRoutes(app)
+	JobRoutes(app)
+	TemplateKegiatanRoutes(app)
+	ImportRoutes(app)
+	TamuRoutes(app)
+	PublicRoutes(app)
+	CheckinRoutes(app)
+	UploadRoutes(app)
+}
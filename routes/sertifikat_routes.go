@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func SertifikatRoutes(app fiber.Router) {
+	app.Get("/certificates/:serial/verify", controller.VerifySertifikat)
+	app.Get("/me/sertifikat", middleware.AuthRequired(), controller.GetMySertifikat)
+	app.Get("/sertifikat/:serial/pdf", middleware.AuthRequired(), controller.DownloadSertifikat)
+	app.Get("/kegiatan/:id/sertifikat", middleware.AuthRequired(), middleware.RoleRequired("admin", "reviewer"), controller.GetSertifikatKegiatan)
+	app.Get("/kegiatan/:id/sertifikat/template", middleware.AuthRequired(), controller.GetTemplateSertifikat)
+	app.Put("/kegiatan/:id/sertifikat/template", middleware.AuthRequired(), middleware.AdminOnly(), controller.SaveTemplateSertifikat)
+	app.Post("/kegiatan/:id/sertifikat/generate", middleware.AuthRequired(), middleware.AdminOnly(), controller.GenerateSertifikat)
+	app.Post("/kegiatan/:id/sertifikat/me", middleware.AuthRequired(), controller.ClaimSertifikat)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TamuRoutes(app fiber.Router) {
+	app.Get("/kegiatan/:id/tamu", middleware.AuthRequired(), middleware.RoleRequired("admin", "reviewer"), controller.GetTamu)
+	app.Put("/kegiatan/:id/tamu/:tamuId/kehadiran", middleware.AuthRequired(), middleware.AdminOnly(), controller.UpdateKehadiranTamu)
+	app.Post("/kegiatan/:id/tamu/:tamuId/convert", middleware.AuthRequired(), middleware.AdminOnly(), controller.KonversiTamu)
+	app.Delete("/kegiatan/:id/tamu/:tamuId", middleware.AuthRequired(), middleware.AdminOnly(), controller.DeleteTamu)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TemplateKegiatanRoutes(app fiber.Router) {
+	app.Post("/kegiatan/:id/duplicate", middleware.AuthRequired(), middleware.AdminOnly(), controller.DuplicateKegiatan)
+	app.Post("/kegiatan/:id/template", middleware.AuthRequired(), middleware.AdminOnly(), controller.SaveKegiatanAsTemplate)
+	app.Get("/template-kegiatan", middleware.AuthRequired(), middleware.AdminOnly(), controller.GetTemplateKegiatan)
+	app.Get("/template-kegiatan/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.GetTemplateKegiatanByID)
+	app.Post("/template-kegiatan/:id/kegiatan", middleware.AuthRequired(), middleware.AdminOnly(), controller.CreateKegiatanFromTemplate)
+	app.Delete("/template-kegiatan/:id", middleware.AuthRequired(), middleware.AdminOnly(), controller.DeleteTemplateKegiatan)
+}
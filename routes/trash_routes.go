@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TrashRoutes(app fiber.Router) {
+	app.Get("/trash", middleware.AuthRequired(), middleware.AdminOnly(), controller.GetTrash)
+	app.Post("/kegiatan/:id/restore", middleware.AuthRequired(), middleware.AdminOnly(), controller.RestoreKegiatan)
+	app.Post("/kehadiran/:id/restore", middleware.AuthRequired(), middleware.AdminOnly(), controller.RestoreKehadiran)
+	app.Post("/kategori/:id/restore", middleware.AuthRequired(), middleware.AdminOnly(), controller.RestoreKategori)
+	app.Post("/users/:id/restore", middleware.AuthRequired(), middleware.AdminOnly(), controller.RestoreUser)
+}
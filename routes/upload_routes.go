@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"backend-sisteminformasi/controller"
+	"backend-sisteminformasi/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func UploadRoutes(app fiber.Router) {
+	app.Get("/uploads/:folder/:name", middleware.AuthRequired(), controller.DownloadUpload)
+}
@@ -0,0 +1,246 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"backend-sisteminformasi/config"
+	"backend-sisteminformasi/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Handler menjalankan satu job. Error membuat job dicoba ulang sampai MaxAttempts.
+type Handler func(ctx context.Context, job model.Job) error
+
+const (
+	pollInterval       = 30 * time.Second
+	lockDuration       = 5 * time.Minute
+	defaultMaxAttempts = 3
+)
+
+var (
+	handlersMu sync.RWMutex
+	handlers   = map[string]Handler{}
+
+	// instanceID membedakan replika yang sedang memegang job
+	instanceID = newInstanceID()
+)
+
+func newInstanceID() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	rand.Read(b)
+	return host + "-" + hex.EncodeToString(b)
+}
+
+func collection() *mongo.Collection {
+	return config.DB.Collection("jobs")
+}
+
+// Register mendaftarkan handler untuk tipe job
+func Register(tipe string, handler Handler) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	handlers[tipe] = handler
+}
+
+func handlerFor(tipe string) (Handler, bool) {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+	h, ok := handlers[tipe]
+	return h, ok
+}
+
+// Schedule menyimpan job baru yang akan dijalankan pada runAt
+func Schedule(ctx context.Context, tipe string, runAt time.Time, payload map[string]interface{}) error {
+	now := time.Now()
+	_, err := collection().InsertOne(ctx, model.Job{
+		Tipe:        tipe,
+		Payload:     payload,
+		RunAt:       runAt,
+		Status:      model.JobPending,
+		MaxAttempts: defaultMaxAttempts,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	return err
+}
+
+// ScheduleUnique membuat atau menjadwalkan ulang job dengan key tertentu.
+// Jika job dengan key dan runAt yang sama sudah ada (termasuk yang sudah selesai),
+// tidak ada yang berubah sehingga aman dipanggil berulang kali.
+func ScheduleUnique(ctx context.Context, key, tipe string, runAt time.Time, payload map[string]interface{}) error {
+	now := time.Now()
+	filter := bson.M{"unique_key": key, "run_at": bson.M{"$ne": runAt}}
+	update := bson.M{
+		"$set": bson.M{
+			"tipe":         tipe,
+			"payload":      payload,
+			"run_at":       runAt,
+			"status":       model.JobPending,
+			"attempts":     0,
+			"max_attempts": defaultMaxAttempts,
+			"updated_at":   now,
+		},
+		"$unset":       bson.M{"locked_by": "", "locked_until": "", "last_error": "", "finished_at": ""},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	_, err := collection().UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// EnsureRecurring memastikan job berulang dengan key tertentu ada. Job yang
+// sudah ada tidak diubah, sehingga jadwalnya tetap sama setelah restart.
+func EnsureRecurring(ctx context.Context, key, tipe string, interval time.Duration) error {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"interval": interval},
+		"$setOnInsert": bson.M{
+			"tipe":         tipe,
+			"run_at":       now,
+			"status":       model.JobPending,
+			"attempts":     0,
+			"max_attempts": defaultMaxAttempts,
+			"created_at":   now,
+			"updated_at":   now,
+		},
+	}
+	_, err := collection().UpdateOne(ctx, bson.M{"unique_key": key}, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// Cancel menghapus job dengan key tertentu yang belum dijalankan
+func Cancel(ctx context.Context, key string) error {
+	_, err := collection().DeleteMany(ctx, bson.M{"unique_key": key, "status": model.JobPending})
+	return err
+}
+
+// claim mengambil satu job yang sudah jatuh tempo secara atomik. Job running yang
+// lock-nya kedaluwarsa (replika mati di tengah jalan) ikut diambil ulang.
+func claim(ctx context.Context) (model.Job, error) {
+	now := time.Now()
+	lockedUntil := now.Add(lockDuration)
+	filter := bson.M{
+		"run_at": bson.M{"$lte": now},
+		"$or": []bson.M{
+			{"status": model.JobPending},
+			{"status": model.JobRunning, "locked_until": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       model.JobRunning,
+			"locked_by":    instanceID,
+			"locked_until": lockedUntil,
+			"updated_at":   now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().SetSort(bson.M{"run_at": 1}).SetReturnDocument(options.After)
+	var job model.Job
+	err := collection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	return job, err
+}
+
+// finish mencatat hasil job. Hanya replika pemegang lock yang boleh mengubahnya.
+func finish(ctx context.Context, job model.Job, runErr error) error {
+	now := time.Now()
+	set := bson.M{"updated_at": now}
+	update := bson.M{"$set": set, "$unset": bson.M{"locked_by": "", "locked_until": ""}}
+	switch {
+	case runErr == nil && job.Interval > 0:
+		set["status"] = model.JobPending
+		set["run_at"] = now.Add(job.Interval)
+		set["attempts"] = 0
+		set["finished_at"] = now
+		update["$unset"].(bson.M)["last_error"] = ""
+	case runErr == nil:
+		set["status"] = model.JobDone
+		set["finished_at"] = now
+		update["$unset"].(bson.M)["last_error"] = ""
+	case job.Attempts < job.MaxAttempts:
+		set["status"] = model.JobPending
+		set["run_at"] = now.Add(time.Duration(job.Attempts) * time.Minute)
+		set["last_error"] = runErr.Error()
+	case job.Interval > 0:
+		// Job berulang tidak pernah berhenti, tunggu jadwal berikutnya
+		set["status"] = model.JobPending
+		set["run_at"] = now.Add(job.Interval)
+		set["attempts"] = 0
+		set["last_error"] = runErr.Error()
+	default:
+		set["status"] = model.JobFailed
+		set["finished_at"] = now
+		set["last_error"] = runErr.Error()
+	}
+	_, err := collection().UpdateOne(ctx, bson.M{"_id": job.ID, "locked_by": instanceID}, update)
+	return err
+}
+
+func run(job model.Job) (err error) {
+	handler, ok := handlerFor(job.Tipe)
+	if !ok {
+		return fmt.Errorf("no handler registered for job %q", job.Tipe)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), lockDuration)
+	defer cancel()
+	return handler(ctx, job)
+}
+
+// RunDue menjalankan semua job yang sudah jatuh tempo satu per satu
+func RunDue() {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		job, err := claim(ctx)
+		cancel()
+		if err == mongo.ErrNoDocuments {
+			return
+		}
+		if err != nil {
+			log.Println("Scheduler: gagal mengambil job:", err)
+			return
+		}
+
+		runErr := run(job)
+		if runErr != nil {
+			log.Println("Scheduler: job", job.Tipe, job.ID.Hex(), "gagal:", runErr)
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		if err := finish(ctx, job, runErr); err != nil {
+			log.Println("Scheduler: gagal menyimpan hasil job", job.ID.Hex(), ":", err)
+		}
+		cancel()
+	}
+}
+
+// Start menjalankan scheduler di background, memeriksa job jatuh tempo setiap 30 detik
+func Start() {
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			RunDue()
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CheckinInterval adalah lama satu kode QR check-in berlaku sebelum berganti,
+// diatur lewat CHECKIN_QR_INTERVAL dalam detik (default 30)
+func CheckinInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("CHECKIN_QR_INTERVAL"))
+	if err != nil || seconds < 5 {
+		seconds = 30
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+var ErrCheckinSecretKosong = errors.New("CHECKIN_SECRET or JWT_SECRET must be set")
+
+// checkinSecret memakai CHECKIN_SECRET, atau kunci turunan HMAC(JWT_SECRET, "checkin") agar
+// kode QR tidak ditandatangani dengan kunci yang sama dengan JWT. Kosong jika keduanya tidak diatur.
+func checkinSecret() []byte {
+	if secret := os.Getenv("CHECKIN_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		return nil
+	}
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte("checkin"))
+	return mac.Sum(nil)
+}
+
+func signCheckin(secret []byte, kegiatanID string, window int64) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(kegiatanID + "." + strconv.FormatInt(window, 10)))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// GenerateCheckinToken membuat kode QR untuk kegiatan pada jendela waktu saat ini.
+// Format: <kegiatan_id>.<jendela>.<hmac>
+func GenerateCheckinToken(kegiatanID string, now time.Time) (string, time.Time, error) {
+	secret := checkinSecret()
+	if len(secret) == 0 {
+		return "", time.Time{}, ErrCheckinSecretKosong
+	}
+	interval := CheckinInterval()
+	window := now.Unix() / int64(interval.Seconds())
+	expiresAt := time.Unix((window+1)*int64(interval.Seconds()), 0)
+	token := kegiatanID + "." + strconv.FormatInt(window, 10) + "." + signCheckin(secret, kegiatanID, window)
+	return token, expiresAt, nil
+}
+
+// VerifyCheckinToken memastikan kode ditandatangani server untuk kegiatan tersebut dan
+// masih berlaku. Kode dari jendela sebelumnya tetap diterima agar pemindaian di detik
+// terakhir tidak gagal. Semua kode ditolak jika kunci penandatangan tidak diatur.
+func VerifyCheckinToken(token, kegiatanID string, now time.Time) bool {
+	secret := checkinSecret()
+	if len(secret) == 0 {
+		return false
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != kegiatanID {
+		return false
+	}
+	window, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return false
+	}
+	current := now.Unix() / int64(CheckinInterval().Seconds())
+	if window != current && window != current-1 {
+		return false
+	}
+	return hmac.Equal([]byte(parts[2]), []byte(signCheckin(secret, kegiatanID, window)))
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckinTokenSecret(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	kegiatanID := "65f000000000000000000001"
+
+	t.Setenv("CHECKIN_SECRET", "")
+	t.Setenv("JWT_SECRET", "")
+	if _, _, err := GenerateCheckinToken(kegiatanID, now); err == nil {
+		t.Fatal("GenerateCheckinToken() without secret, want error")
+	}
+	if VerifyCheckinToken(kegiatanID+".56666666.00000000000000000000000000000000", kegiatanID, now) {
+		t.Fatal("VerifyCheckinToken() without secret = true, want false")
+	}
+
+	t.Setenv("JWT_SECRET", "rahasia")
+	token, _, err := GenerateCheckinToken(kegiatanID, now)
+	if err != nil {
+		t.Fatalf("GenerateCheckinToken() error = %v", err)
+	}
+	if !VerifyCheckinToken(token, kegiatanID, now) {
+		t.Fatal("VerifyCheckinToken() with derived secret = false, want true")
+	}
+	// Kunci turunan tidak sama dengan JWT_SECRET mentah
+	t.Setenv("CHECKIN_SECRET", "rahasia")
+	if VerifyCheckinToken(token, kegiatanID, now) {
+		t.Fatal("token signed with derived key accepted with raw JWT_SECRET")
+	}
+	if VerifyCheckinToken(token, "65f000000000000000000002", now) {
+		t.Fatal("VerifyCheckinToken() for another kegiatan = true, want false")
+	}
+}
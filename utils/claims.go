@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// GetClaims mengambil claims JWT yang sudah diverifikasi oleh middleware AuthRequired
+func GetClaims(c *fiber.Ctx) jwt.MapClaims {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return nil
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+	return claims
+}
+
+// GetUserID mengembalikan claim "id" milik user yang sedang login
+func GetUserID(c *fiber.Ctx) string {
+	id, _ := GetClaims(c)["id"].(string)
+	return id
+}
+
+// GetUserRole mengembalikan claim "role" milik user yang sedang login
+func GetUserRole(c *fiber.Ctx) string {
+	role, _ := GetClaims(c)["role"].(string)
+	return role
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+// Zona waktu kampus, dipakai untuk tanggal kegiatan yang tidak menyertakan offset
+var Location = loadLocation()
+
+func loadLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+var tanggalLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTanggal membaca field tanggal kegiatan yang disimpan sebagai string
+// (ISO 8601 lengkap, tanggal+jam, atau tanggal saja)
+func ParseTanggal(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range tanggalLayouts {
+		if t, err := time.ParseInLocation(layout, value, Location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("format tanggal tidak dikenali: " + value)
+}
+
+var namaBulan = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// FormatTanggalIndonesia menulis tanggal dalam format "2 Januari 2006"
+func FormatTanggalIndonesia(t time.Time) string {
+	t = t.In(Location)
+	return strconv.Itoa(t.Day()) + " " + namaBulan[t.Month()-1] + " " + strconv.Itoa(t.Year())
+}
+
+// Semester mengembalikan semester akademik tanggal tersebut, mis. "2025/2026 Ganjil".
+// Semester ganjil berjalan Agustus-Januari dan genap Februari-Juli.
+func Semester(t time.Time) string {
+	t = t.In(Location)
+	year := t.Year()
+	switch {
+	case t.Month() >= time.August:
+		return strconv.Itoa(year) + "/" + strconv.Itoa(year+1) + " Ganjil"
+	case t.Month() == time.January:
+		return strconv.Itoa(year-1) + "/" + strconv.Itoa(year) + " Ganjil"
+	}
+	return strconv.Itoa(year-1) + "/" + strconv.Itoa(year) + " Genap"
+}
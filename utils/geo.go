@@ -0,0 +1,15 @@
+package utils
+
+import "math"
+
+const radiusBumiMeter = 6371000
+
+// JarakMeter menghitung jarak dua koordinat dengan rumus haversine
+func JarakMeter(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * radiusBumiMeter * math.Asin(math.Sqrt(a))
+}
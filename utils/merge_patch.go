@@ -0,0 +1,37 @@
+package utils
+
+import "encoding/json"
+
+// MergePatch menerapkan JSON Merge Patch (RFC 7386) pada dokumen original.
+// Field bernilai null pada patch akan dihapus, object digabung secara rekursif,
+// dan nilai lain menggantikan nilai lama.
+func MergePatch(original, patch []byte) ([]byte, error) {
+	var target interface{}
+	if err := json.Unmarshal(original, &target); err != nil {
+		return nil, err
+	}
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeValue(target, patchValue))
+}
+
+func mergeValue(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergeValue(targetObj[key], value)
+	}
+	return targetObj
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// DataSertifikat berisi teks yang dicetak pada sertifikat
+type DataSertifikat struct {
+	Serial        string
+	Judul         string
+	NamaPeserta   string
+	Keterangan    string
+	JudulKegiatan string
+	UKM           string
+	Tanggal       string
+	Penandatangan []PenandatanganSertifikat
+	VerifyURL     string
+}
+
+type PenandatanganSertifikat struct {
+	Nama    string
+	Jabatan string
+}
+
+// RenderSertifikatPDF membuat sertifikat A4 landscape beserta QR code ke VerifyURL
+func RenderSertifikatPDF(data DataSertifikat) ([]byte, error) {
+	qr, err := qrcode.Encode(data.VerifyURL, qrcode.Medium, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle(data.Judul+" - "+data.NamaPeserta, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	pageW, pageH := pdf.GetPageSize()
+	contentW := pageW - 40
+
+	// Bingkai
+	pdf.SetDrawColor(30, 64, 120)
+	pdf.SetLineWidth(1.5)
+	pdf.Rect(10, 10, pageW-20, pageH-20, "D")
+	pdf.SetLineWidth(0.4)
+	pdf.Rect(14, 14, pageW-28, pageH-28, "D")
+
+	pdf.SetTextColor(30, 64, 120)
+	pdf.SetFont("Helvetica", "B", 30)
+	pdf.SetXY(20, 32)
+	pdf.CellFormat(contentW, 14, tr(data.Judul), "", 1, "C", false, 0, "")
+
+	pdf.SetTextColor(60, 60, 60)
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(contentW, 7, tr("Nomor: "+data.Serial), "", 1, "C", false, 0, "")
+
+	pdf.Ln(8)
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.CellFormat(contentW, 8, tr("Diberikan kepada"), "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 26)
+	pdf.CellFormat(contentW, 14, tr(data.NamaPeserta), "", 1, "C", false, 0, "")
+
+	pdf.Ln(4)
+	pdf.SetTextColor(60, 60, 60)
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.MultiCell(contentW, 7, tr(data.Keterangan), "", "C", false)
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.MultiCell(contentW, 8, tr(data.JudulKegiatan), "", "C", false)
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.CellFormat(contentW, 7, tr(fmt.Sprintf("diselenggarakan oleh %s pada %s", data.UKM, data.Tanggal)), "", 1, "C", false, 0, "")
+
+	// Tanda tangan dibagi rata di bagian bawah, menyisakan ruang untuk QR di kiri
+	const qrSize = 32.0
+	signY := pageH - 55
+	if n := len(data.Penandatangan); n > 0 {
+		left := 20 + qrSize + 10
+		colW := (pageW - 20 - left) / float64(n)
+		for i, p := range data.Penandatangan {
+			x := left + float64(i)*colW
+			pdf.SetFont("Helvetica", "", 11)
+			pdf.SetXY(x, signY)
+			pdf.CellFormat(colW, 6, tr(p.Jabatan), "", 0, "C", false, 0, "")
+			pdf.Line(x+10, signY+26, x+colW-10, signY+26)
+			pdf.SetFont("Helvetica", "B", 11)
+			pdf.SetXY(x, signY+27)
+			pdf.CellFormat(colW, 6, tr(p.Nama), "", 0, "C", false, 0, "")
+		}
+	}
+
+	pdf.RegisterImageOptionsReader("qr", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions("qr", 22, pageH-22-qrSize, qrSize, qrSize, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, data.VerifyURL)
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.SetXY(22, pageH-21)
+	pdf.CellFormat(qrSize, 4, "Pindai untuk verifikasi", "", 0, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
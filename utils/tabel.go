@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadTable membaca file CSV atau XLSX (sheet pertama) menjadi baris-baris sel
+func ReadTable(filename string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return readCSV(data)
+	case ".xlsx":
+		return readXLSX(data)
+	default:
+		return nil, errors.New("File type not supported, use .csv or .xlsx")
+	}
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	// Excel berlokal Indonesia menyimpan CSV dengan pemisah titik koma
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader.ReadAll()
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (r xlsxRichText) String() string {
+	if len(r.Runs) == 0 {
+		return r.Text
+	}
+	var sb strings.Builder
+	for _, run := range r.Runs {
+		sb.WriteString(run.Text)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readZipXML(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return errors.New("missing " + name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v)
+}
+
+// firstSheetPath mencari lokasi sheet pertama lewat workbook.xml dan relasinya
+func firstSheetPath(files map[string]*zip.File) string {
+	var workbook xlsxWorkbook
+	var rels xlsxRelationships
+	if readZipXML(files, "xl/workbook.xml", &workbook) != nil || len(workbook.Sheets) == 0 ||
+		readZipXML(files, "xl/_rels/workbook.xml.rels", &rels) != nil {
+		return "xl/worksheets/sheet1.xml"
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RelID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/")
+			}
+			return path.Join("xl", rel.Target)
+		}
+	}
+	return "xl/worksheets/sheet1.xml"
+}
+
+// Indeks kolom terakhir yang didukung Excel (kolom XFD)
+const maxKolomXLSX = 16383
+
+// columnIndex mengubah referensi sel seperti "C12" menjadi indeks kolom 2.
+// Referensi melewati kolom XFD menghasilkan maxKolomXLSX+1.
+func columnIndex(ref string) int {
+	col := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+		if col-1 > maxKolomXLSX {
+			return maxKolomXLSX + 1
+		}
+	}
+	return col - 1
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("Invalid XLSX file")
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	var shared xlsxSharedStrings
+	// sharedStrings.xml tidak ada jika sheet hanya berisi angka
+	_ = readZipXML(files, "xl/sharedStrings.xml", &shared)
+	var sheet xlsxSheet
+	if err := readZipXML(files, firstSheetPath(files), &sheet); err != nil {
+		return nil, errors.New("Invalid XLSX file")
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, r := range sheet.Rows {
+		row := []string{}
+		for i, cell := range r.Cells {
+			col := i
+			if cell.Ref != "" {
+				col = columnIndex(cell.Ref)
+			}
+			if col > maxKolomXLSX {
+				return nil, errors.New("Invalid XLSX file, column is beyond XFD")
+			}
+			if col < 0 {
+				continue
+			}
+			for len(row) <= col {
+				row = append(row, "")
+			}
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err == nil && idx >= 0 && idx < len(shared.Items) {
+					row[col] = shared.Items[idx].String()
+				}
+			case "inlineStr":
+				row[col] = cell.Inline.String()
+			case "b":
+				row[col] = map[string]string{"1": "true", "0": "false"}[cell.Value]
+			default:
+				row[col] = cell.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ExcelSerialToTime mengubah nomor seri tanggal Excel (mis. 45658.5) menjadi waktu
+func ExcelSerialToTime(value string) (time.Time, bool) {
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil || serial <= 0 || serial > 2958465 {
+		return time.Time{}, false
+	}
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 86400)
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, Location)
+	return base.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second), true
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want int
+	}{
+		{"A1", 0},
+		{"C12", 2},
+		{"Z3", 25},
+		{"AA1", 26},
+		{"AZ1", 51},
+		{"XFD1", maxKolomXLSX},
+		{"XFE1", maxKolomXLSX + 1},
+		{"ZZZZZZZZZZZZZZZZ1", maxKolomXLSX + 1},
+		{"12", -1},
+		{"", -1},
+	}
+	for _, tt := range tests {
+		if got := columnIndex(tt.ref); got != tt.want {
+			t.Errorf("columnIndex(%q) = %d, want %d", tt.ref, got, tt.want)
+		}
+	}
+}
+
+// buatXLSX menyusun file XLSX minimal berisi sheet1 dan sharedStrings opsional
+func buatXLSX(t *testing.T, sheet, shared string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{"xl/worksheets/sheet1.xml": sheet}
+	if shared != "" {
+		files["xl/sharedStrings.xml"] = shared
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const sheetNS = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+func TestReadTable(t *testing.T) {
+	shared := `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<si><t>nama</t></si><si><t>email</t></si><si><r><t>Budi </t></r><r><t>Santoso</t></r></si></sst>`
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		want     [][]string
+		wantErr  bool
+	}{
+		{
+			name:     "csv koma",
+			filename: "users.csv",
+			data:     []byte("nama,email\nBudi,budi@example.com\n"),
+			want:     [][]string{{"nama", "email"}, {"Budi", "budi@example.com"}},
+		},
+		{
+			name:     "csv titik koma dengan BOM",
+			filename: "USERS.CSV",
+			data:     []byte("\xef\xbb\xbfnama;email\nBudi; budi@example.com\n"),
+			want:     [][]string{{"nama", "email"}, {"Budi", "budi@example.com"}},
+		},
+		{
+			name:     "csv jumlah kolom berbeda",
+			filename: "users.csv",
+			data:     []byte("nama,email,ukm\nBudi\n"),
+			want:     [][]string{{"nama", "email", "ukm"}, {"Budi"}},
+		},
+		{
+			name:     "xlsx shared string, inline, boolean dan kolom kosong",
+			filename: "users.xlsx",
+			data: buatXLSX(t, sheetNS+
+				`<row><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="inlineStr"><is><t>aktif</t></is></c></row>`+
+				`<row><c r="A2" t="s"><v>2</v></c><c r="B2" t="str"><v>budi@example.com</v></c><c r="D2" t="b"><v>1</v></c></row>`+
+				`</sheetData></worksheet>`, shared),
+			want: [][]string{
+				{"nama", "email", "", "aktif"},
+				{"Budi Santoso", "budi@example.com", "", "true"},
+			},
+		},
+		{
+			name:     "xlsx tanpa referensi sel dan tanpa sharedStrings",
+			filename: "angka.xlsx",
+			data:     buatXLSX(t, sheetNS+`<row><c><v>1</v></c><c><v>45658.5</v></c></row></sheetData></worksheet>`, ""),
+			want:     [][]string{{"1", "45658.5"}},
+		},
+		{
+			name:     "xlsx kolom melewati XFD",
+			filename: "besar.xlsx",
+			data:     buatXLSX(t, sheetNS+`<row><c r="ZZZZZZZ1"><v>1</v></c></row></sheetData></worksheet>`, ""),
+			wantErr:  true,
+		},
+		{
+			name:     "xlsx rusak",
+			filename: "rusak.xlsx",
+			data:     []byte("bukan zip"),
+			wantErr:  true,
+		},
+		{
+			name:     "ekstensi tidak didukung",
+			filename: "users.xls",
+			data:     []byte("nama\n"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadTable(tt.filename, tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ReadTable() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadTable() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadTable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxUploadSize = 10 * 1024 * 1024
+
+var allowedUploadExt = map[string]bool{
+	".pdf": true, ".jpg": true, ".jpeg": true, ".png": true,
+	".doc": true, ".docx": true, ".xls": true, ".xlsx": true,
+}
+
+// UploadDir adalah folder penyimpanan file upload (UPLOAD_DIR, default "uploads")
+func UploadDir() string {
+	if dir := os.Getenv("UPLOAD_DIR"); dir != "" {
+		return dir
+	}
+	return "uploads"
+}
+
+// SaveUpload menyimpan file multipart dari field form ke UploadDir()/folder
+// dan mengembalikan URL unduhannya (/uploads/folder/nama-file) yang dilayani DownloadUpload
+func SaveUpload(c *fiber.Ctx, field, folder string) (string, error) {
+	file, err := c.FormFile(field)
+	if err != nil {
+		return "", errors.New("File is required")
+	}
+	if file.Size > maxUploadSize {
+		return "", errors.New("File too large (max 10MB)")
+	}
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !allowedUploadExt[ext] {
+		return "", errors.New("File type not allowed")
+	}
+	dir := filepath.Join(UploadDir(), folder)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := primitive.NewObjectID().Hex() + ext
+	if err := c.SaveFile(file, filepath.Join(dir, name)); err != nil {
+		return "", err
+	}
+	return "/uploads/" + folder + "/" + name, nil
+}